@@ -0,0 +1,267 @@
+// Package auth implements the [request tokens] customers present to PFIs in order to read their exchanges and balances.
+//
+// [request tokens]: https://github.com/TBD54566975/tbdex/tree/main/specs/http-api#protected-endpoints
+package auth
+
+import (
+	"container/heap"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
+	web5crypto "github.com/tbd54566975/web5-go/crypto"
+	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/jwt"
+)
+
+const (
+	// DefaultTTL is how long request tokens remain valid unless a custom TTL is provided.
+	DefaultTTL = time.Minute
+	// DefaultClockSkew is the clock skew tolerated by a [Verifier] unless a custom skew is provided.
+	DefaultClockSkew = 30 * time.Second
+	// DefaultMaxLifetime is the longest lifetime, from iat to exp, of the request tokens accepted by a [Verifier]
+	// unless a custom maximum is provided.
+	DefaultMaxLifetime = DefaultTTL + DefaultClockSkew
+)
+
+type createOptions struct {
+	issuedAt time.Time
+	ttl      time.Duration
+	clock    clock.Clock
+}
+
+// CreateOption implements functional options pattern for [CreateRequestToken].
+type CreateOption func(*createOptions)
+
+// IssuedAt can be passed to [CreateRequestToken] to provide a custom issued at time.
+func IssuedAt(t time.Time) CreateOption {
+	return func(o *createOptions) {
+		o.issuedAt = t
+	}
+}
+
+// Clock can be passed to [CreateRequestToken] to provide the clock used for the default issued at time.
+// Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(o *createOptions) {
+		o.clock = c
+	}
+}
+
+// TTL can be passed to [CreateRequestToken] to provide a custom lifetime. Defaults to [DefaultTTL].
+func TTL(ttl time.Duration) CreateOption {
+	return func(o *createOptions) {
+		o.ttl = ttl
+	}
+}
+
+// CreateRequestToken creates a signed JWT that authenticates the requester with the PFI identified by pfiDID.
+// The token includes the following claims:
+//   - iss: the requester's DID
+//   - aud: the PFI's DID
+//   - iat: the time the token was issued
+//   - exp: the time after which the token must be rejected
+//   - jti: a random unique identifier used to prevent replay
+func CreateRequestToken(requester did.BearerDID, pfiDID string, opts ...CreateOption) (string, error) {
+	o := createOptions{
+		ttl: DefaultTTL,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.issuedAt.IsZero() {
+		o.issuedAt = clock.OrDefault(o.clock).Now()
+	}
+
+	jti, err := web5crypto.GenerateEntropy(web5crypto.Entropy128)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate request token id: %w", err)
+	}
+
+	claims := jwt.Claims{
+		Audience:   pfiDID,
+		IssuedAt:   o.issuedAt.Unix(),
+		Expiration: o.issuedAt.Add(o.ttl).Unix(),
+		JTI:        base64.RawURLEncoding.EncodeToString(jti),
+	}
+
+	token, err := jwt.Sign(claims, requester)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign request token: %w", err)
+	}
+
+	return token, nil
+}
+
+// Verifier verifies request tokens on behalf of a PFI. It remembers the id of every token it accepts until the
+// token expires so that each token can only be used once. Tokens with a lifetime longer than the maximum are
+// rejected, which bounds how long ids are remembered. Verifier is safe for concurrent use.
+type Verifier struct {
+	pfiDID      string
+	skew        time.Duration
+	maxLifetime time.Duration
+	clock       clock.Clock
+
+	mu       sync.Mutex
+	seen     map[string]struct{}
+	expiries expiryHeap
+}
+
+// usedToken is the id of a token that has been used along with the time after which it can be forgotten.
+type usedToken struct {
+	jti       string
+	expiresAt time.Time
+}
+
+// expiryHeap is a min-heap of used tokens ordered by when they expire.
+type expiryHeap []usedToken
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h expiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x any)        { *h = append(*h, x.(usedToken)) }
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return last
+}
+
+// VerifierOption implements functional options pattern for [NewVerifier].
+type VerifierOption func(*Verifier)
+
+// ClockSkew can be passed to [NewVerifier] to provide a custom tolerance for differences between the
+// requester's clock and the PFI's clock. Defaults to [DefaultClockSkew].
+func ClockSkew(skew time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.skew = skew
+	}
+}
+
+// MaxLifetime can be passed to [NewVerifier] to provide the longest lifetime, from iat to exp, of the tokens
+// that are accepted. Defaults to [DefaultMaxLifetime].
+func MaxLifetime(d time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.maxLifetime = d
+	}
+}
+
+// VerifierClock can be passed to [NewVerifier] to provide the clock used to check when tokens were issued and
+// when they expire. Defaults to the clock carried by the context passed to [Verifier.Verify], see
+// [clock.FromContext].
+func VerifierClock(c clock.Clock) VerifierOption {
+	return func(v *Verifier) {
+		v.clock = c
+	}
+}
+
+// NewVerifier creates a [Verifier] that accepts request tokens addressed to pfiDID.
+func NewVerifier(pfiDID string, opts ...VerifierOption) *Verifier {
+	v := &Verifier{
+		pfiDID:      pfiDID,
+		skew:        DefaultClockSkew,
+		maxLifetime: DefaultMaxLifetime,
+		seen:        make(map[string]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Verify verifies the request token and returns the requester's DID. The following checks are performed:
+//   - aud matches the PFI's DID
+//   - iat is not in the future
+//   - exp has not passed
+//   - exp is no later than the maximum lifetime after iat
+//   - jti has not been used before
+//   - the token is signed by the DID in iss
+func (v *Verifier) Verify(ctx context.Context, token string) (string, error) {
+	decoded, err := jwt.Decode(token)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode request token: %w", err)
+	}
+
+	c := v.clock
+	if c == nil {
+		c = clock.FromContext(ctx)
+	}
+
+	claims := decoded.Claims
+	now := c.Now()
+
+	if claims.Audience != v.pfiDID {
+		return "", fmt.Errorf("request token audience: %s does not match pfi: %s", claims.Audience, v.pfiDID)
+	}
+
+	if claims.IssuedAt == 0 {
+		return "", errors.New("request token is missing iat")
+	}
+
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if issuedAt.After(now.Add(v.skew)) {
+		return "", errors.New("request token was issued in the future")
+	}
+
+	if claims.Expiration == 0 {
+		return "", errors.New("request token is missing exp")
+	}
+
+	expiresAt := time.Unix(claims.Expiration, 0)
+	if now.After(expiresAt.Add(v.skew)) {
+		return "", errors.New("request token has expired")
+	}
+
+	if lifetime := expiresAt.Sub(issuedAt); lifetime <= 0 || lifetime > v.maxLifetime {
+		return "", fmt.Errorf("request token lifetime: %s must be positive and at most %s", lifetime, v.maxLifetime)
+	}
+
+	if claims.JTI == "" {
+		return "", errors.New("request token is missing jti")
+	}
+
+	if claims.Issuer == "" || decoded.SignerDID.URI != claims.Issuer {
+		return "", errors.New("request token issuer does not match signing key")
+	}
+
+	if _, err := crypto.VerifyJWSContext(ctx, token); err != nil {
+		return "", fmt.Errorf("failed to verify request token signature: %w", err)
+	}
+
+	if err := v.markUsed(claims.JTI, expiresAt.Add(v.skew), now); err != nil {
+		return "", err
+	}
+
+	return claims.Issuer, nil
+}
+
+// markUsed records the token id until it expires, forgetting the ids of tokens that have expired by now.
+// An error is returned if the id has already been used.
+func (v *Verifier) markUsed(jti string, expiresAt, now time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for len(v.expiries) > 0 && now.After(v.expiries[0].expiresAt) {
+		delete(v.seen, heap.Pop(&v.expiries).(usedToken).jti)
+	}
+
+	if _, ok := v.seen[jti]; ok {
+		return errors.New("request token has already been used")
+	}
+
+	v.seen[jti] = struct{}{}
+	heap.Push(&v.expiries, usedToken{jti: jti, expiresAt: expiresAt})
+
+	return nil
+}
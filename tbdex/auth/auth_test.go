@@ -0,0 +1,215 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/auth"
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+	"github.com/tbd54566975/web5-go/jws"
+	"github.com/tbd54566975/web5-go/jwt"
+)
+
+func TestCreateRequestToken(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	token, err := auth.CreateRequestToken(walletDID, pfiDID.URI)
+	assert.NoError(t, err)
+
+	decoded, err := jwt.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, walletDID.URI, decoded.Claims.Issuer)
+	assert.Equal(t, pfiDID.URI, decoded.Claims.Audience)
+	assert.NotZero(t, decoded.Claims.JTI)
+	assert.Equal(t, int64(auth.DefaultTTL.Seconds()), decoded.Claims.Expiration-decoded.Claims.IssuedAt)
+}
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	verifier := auth.NewVerifier(pfiDID.URI)
+
+	token, err := auth.CreateRequestToken(walletDID, pfiDID.URI)
+	assert.NoError(t, err)
+
+	requester, err := verifier.Verify(ctx, token)
+	assert.NoError(t, err)
+	assert.Equal(t, walletDID.URI, requester)
+
+	_, err = verifier.Verify(ctx, token)
+	assert.Error(t, err)
+}
+
+func TestVerifier_Rejects(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	tests := []struct {
+		name   string
+		pfiDID string
+		opts   []auth.CreateOption
+	}{
+		{name: "wrong audience", pfiDID: walletDID.URI},
+		{name: "expired", pfiDID: pfiDID.URI, opts: []auth.CreateOption{auth.IssuedAt(time.Now().Add(-time.Hour))}},
+		{name: "issued in future", pfiDID: pfiDID.URI, opts: []auth.CreateOption{auth.IssuedAt(time.Now().Add(time.Hour))}},
+	}
+
+	verifier := auth.NewVerifier(pfiDID.URI)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := auth.CreateRequestToken(walletDID, tt.pfiDID, tt.opts...)
+			assert.NoError(t, err)
+
+			_, err = verifier.Verify(ctx, token)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := verifier.Verify(ctx, "not.a.token")
+	assert.Error(t, err)
+}
+
+func TestVerifier_IssuerPrefix(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	attackerDID, _ := didjwk.Create()
+
+	// the attacker claims to be a DID whose URI is a prefix of their own
+	victim := attackerDID.URI[:len(attackerDID.URI)-4]
+
+	now := time.Now()
+	payload, err := json.Marshal(jwt.Claims{
+		Issuer:     victim,
+		Audience:   pfiDID.URI,
+		IssuedAt:   now.Unix(),
+		Expiration: now.Add(time.Minute).Unix(),
+		JTI:        "jti",
+	})
+	assert.NoError(t, err)
+
+	token, err := jws.Sign(payload, attackerDID)
+	assert.NoError(t, err)
+
+	_, err = auth.NewVerifier(pfiDID.URI).Verify(context.Background(), token)
+	assert.Error(t, err)
+}
+
+func TestVerifier_ClockSkew(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	token, err := auth.CreateRequestToken(walletDID, pfiDID.URI, auth.IssuedAt(time.Now().Add(-70*time.Second)))
+	assert.NoError(t, err)
+
+	_, err = auth.NewVerifier(pfiDID.URI, auth.ClockSkew(0)).Verify(ctx, token)
+	assert.Error(t, err)
+
+	_, err = auth.NewVerifier(pfiDID.URI, auth.ClockSkew(time.Minute)).Verify(ctx, token)
+	assert.NoError(t, err)
+}
+
+func TestVerifier_Clock(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	issuedAt := time.Now().Add(-24 * time.Hour)
+
+	token, err := auth.CreateRequestToken(walletDID, pfiDID.URI, auth.Clock(clock.Fixed(issuedAt)))
+	assert.NoError(t, err)
+
+	_, err = auth.NewVerifier(pfiDID.URI).Verify(context.Background(), token)
+	assert.Error(t, err)
+
+	ctx := clock.NewContext(context.Background(), clock.Fixed(issuedAt.Add(10*time.Second)))
+	_, err = auth.NewVerifier(pfiDID.URI).Verify(ctx, token)
+	assert.NoError(t, err)
+
+	_, err = auth.NewVerifier(pfiDID.URI, auth.VerifierClock(clock.Fixed(issuedAt))).Verify(context.Background(), token)
+	assert.NoError(t, err)
+}
+
+func TestVerifier_ConcurrentReplay(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	verifier := auth.NewVerifier(pfiDID.URI)
+
+	token, err := auth.CreateRequestToken(walletDID, pfiDID.URI)
+	assert.NoError(t, err)
+
+	var accepted atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := verifier.Verify(ctx, token); err == nil {
+				accepted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), accepted.Load())
+}
+
+func TestVerifier_MaxLifetime(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	token, err := auth.CreateRequestToken(walletDID, pfiDID.URI, auth.TTL(time.Hour))
+	assert.NoError(t, err)
+
+	_, err = auth.NewVerifier(pfiDID.URI).Verify(ctx, token)
+	assert.Error(t, err)
+
+	_, err = auth.NewVerifier(pfiDID.URI, auth.MaxLifetime(time.Hour)).Verify(ctx, token)
+	assert.NoError(t, err)
+
+	token, err = auth.CreateRequestToken(walletDID, pfiDID.URI, auth.TTL(-time.Second))
+	assert.NoError(t, err)
+
+	_, err = auth.NewVerifier(pfiDID.URI).Verify(ctx, token)
+	assert.Error(t, err)
+}
+
+func TestVerifier_ReplayAfterEviction(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	now := time.Now()
+	verifier := auth.NewVerifier(pfiDID.URI, auth.VerifierClock(clock.Func(func() time.Time { return now })))
+
+	first, err := auth.CreateRequestToken(walletDID, pfiDID.URI, auth.IssuedAt(now))
+	assert.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), first)
+	assert.NoError(t, err)
+
+	// the first token expires and is forgotten when the second is used, which must still only be usable once
+	now = now.Add(2 * time.Minute)
+
+	second, err := auth.CreateRequestToken(walletDID, pfiDID.URI, auth.IssuedAt(now))
+	assert.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), second)
+	assert.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), second)
+	assert.Error(t, err)
+
+	_, err = verifier.Verify(context.Background(), first)
+	assert.Error(t, err)
+}
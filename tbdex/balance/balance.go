@@ -1,12 +1,15 @@
 package balance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/resource"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"go.jetpack.io/typeid"
@@ -48,26 +51,52 @@ func (b Balance) Digest() ([]byte, error) {
 	return hashed, nil
 }
 
+// GetMetadata returns the metadata of the resource
+func (b Balance) GetMetadata() resource.Metadata {
+	return b.Metadata
+}
+
+// GetKind returns the kind of resource
+func (b Balance) GetKind() string {
+	return b.Metadata.Kind
+}
+
+// GetSignature returns the signature of the resource
+func (b Balance) GetSignature() string {
+	return b.Signature
+}
+
 // Create a Balance object
 func Create(fromDID did.BearerDID, currencyCode, availableAmount string, opts ...CreateOption) (Balance, error) {
 	o := createOptions{
-		id:        typeid.Must(typeid.New[ID]()),
-		createdAt: time.Now(),
-		updatedAt: time.Now(),
-		protocol:  "1.0",
+		id:       typeid.Must(typeid.New[ID]()),
+		protocol: "1.0",
 	}
 
 	for _, opt := range opts {
 		opt(&o)
 	}
 
+	now := clock.OrDefault(o.clock).Now()
+	if o.createdAt.IsZero() {
+		o.createdAt = now
+	}
+
+	if o.updatedAt.IsZero() {
+		o.updatedAt = now
+	}
+
+	if !validator.SupportsProtocol(o.protocol) {
+		return Balance{}, fmt.Errorf("unsupported protocol: %s", o.protocol)
+	}
+
 	b := Balance{
 		Metadata: resource.Metadata{
 			From:      fromDID.URI,
 			Kind:      Kind,
 			ID:        o.id.String(),
-			CreatedAt: o.createdAt.UTC().Format(time.RFC3339),
-			UpdatedAt: o.updatedAt.UTC().Format(time.RFC3339),
+			CreatedAt: timestamp.New(o.createdAt),
+			UpdatedAt: timestamp.New(o.updatedAt),
 			Protocol:  o.protocol,
 		},
 		Data: Data{
@@ -91,6 +120,7 @@ type createOptions struct {
 	createdAt time.Time
 	updatedAt time.Time
 	protocol  string
+	clock     clock.Clock
 }
 
 // CreateOption implements functional options pattern for [Create].
@@ -103,6 +133,21 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at and updated at times.
+// Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(o *createOptions) {
+		o.clock = c
+	}
+}
+
+// Protocol can be passed to [Create] to set the protocol version. Defaults to "1.0".
+func Protocol(version string) CreateOption {
+	return func(o *createOptions) {
+		o.protocol = version
+	}
+}
+
 // UpdatedAt can be passed to [Create] to provide a custom updated at time.
 func UpdatedAt(t time.Time) CreateOption {
 	return func(o *createOptions) {
@@ -129,8 +174,13 @@ func (b *Balance) UnmarshalJSON(data []byte) error {
 }
 
 // Verify verifies the signature of the Balance.
-func (b *Balance) Verify() error {
-	decoded, err := crypto.VerifySignature(b, b.Signature)
+func (b Balance) Verify() error {
+	return b.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [Balance.Verify] but uses ctx when resolving the signer's DID.
+func (b Balance) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, b, b.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify Balance signature: %w", err)
 	}
@@ -144,12 +194,17 @@ func (b *Balance) Verify() error {
 
 // Parse validates, parses input data into a Balance, and verifies the signature.
 func Parse(data []byte) (Balance, error) {
+	return ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Parse] but uses ctx when verifying the signature.
+func ParseContext(ctx context.Context, data []byte) (Balance, error) {
 	balance := Balance{}
 	if err := json.Unmarshal(data, &balance); err != nil {
 		return Balance{}, fmt.Errorf("failed to unmarshal Balance: %w", err)
 	}
 
-	if err := balance.Verify(); err != nil {
+	if err := balance.VerifyContext(ctx); err != nil {
 		return Balance{}, fmt.Errorf("failed to verify Balance: %w", err)
 	}
 	return balance, nil
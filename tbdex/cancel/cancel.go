@@ -1,13 +1,16 @@
 package cancel
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/message"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"go.jetpack.io/typeid"
@@ -39,6 +42,11 @@ func (c Cancel) GetKind() string {
 	return c.Metadata.Kind
 }
 
+// GetSignature returns the signature of the message
+func (c Cancel) GetSignature() string {
+	return c.Signature
+}
+
 // GetValidNext returns the kinds of messages that can follow a cancel.
 func (c Cancel) GetValidNext() []string {
 	return ValidNext()
@@ -72,8 +80,13 @@ func (c Cancel) Digest() ([]byte, error) {
 }
 
 // Verify verifies the signature of the Cancel.
-func (c *Cancel) Verify() error {
-	decoded, err := crypto.VerifySignature(c, c.Signature)
+func (c Cancel) Verify() error {
+	return c.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [Cancel.Verify] but uses ctx when resolving the signer's DID.
+func (c Cancel) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, c, c.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify cancel signature: %w", err)
 	}
@@ -105,12 +118,17 @@ func (c *Cancel) UnmarshalJSON(data []byte) error {
 
 // Parse validates and unmarshals the input data into a Cancel.
 func Parse(data []byte) (Cancel, error) {
+	return ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Parse] but uses ctx when verifying the signature.
+func ParseContext(ctx context.Context, data []byte) (Cancel, error) {
 	c := Cancel{}
 	if err := json.Unmarshal(data, &c); err != nil {
 		return Cancel{}, fmt.Errorf("failed to unmarshal Cancel: %w", err)
 	}
 
-	if err := c.Verify(); err != nil {
+	if err := c.VerifyContext(ctx); err != nil {
 		return Cancel{}, fmt.Errorf("failed to verify Cancel: %w", err)
 	}
 
@@ -120,15 +138,23 @@ func Parse(data []byte) (Cancel, error) {
 // Create creates a new Cancel message.
 func Create(fromDID did.BearerDID, to, exchangeID string, opts ...CreateOption) (Cancel, error) {
 	o := createOptions{
-		id:        typeid.Must(typeid.WithPrefix(Kind)).String(),
-		createdAt: time.Now(),
-		protocol:  "1.0",
+		id:       typeid.Must(typeid.WithPrefix(Kind)).String(),
+		protocol: "1.0",
 	}
 
 	for _, opt := range opts {
 		opt(&o)
 	}
 
+	now := clock.OrDefault(o.clock).Now()
+	if o.createdAt.IsZero() {
+		o.createdAt = now
+	}
+
+	if !validator.SupportsProtocol(o.protocol) {
+		return Cancel{}, fmt.Errorf("unsupported protocol: %s", o.protocol)
+	}
+
 	c := Cancel{
 		Metadata: message.Metadata{
 			From:       fromDID.URI,
@@ -136,7 +162,7 @@ func Create(fromDID did.BearerDID, to, exchangeID string, opts ...CreateOption)
 			Kind:       Kind,
 			ID:         o.id,
 			ExchangeID: exchangeID,
-			CreatedAt:  o.createdAt.UTC().Format(time.RFC3339),
+			CreatedAt:  timestamp.New(o.createdAt),
 			ExternalID: o.externalID,
 			Protocol:   o.protocol,
 		},
@@ -159,6 +185,7 @@ type createOptions struct {
 	protocol   string
 	externalID string
 	reason     string
+	clock      clock.Clock
 }
 
 // CreateOption defines a type for functions that can modify the createOptions struct.
@@ -171,6 +198,13 @@ func ID(id string) CreateOption {
 	}
 }
 
+// Protocol can be passed to [Create] to set the protocol version. Defaults to "1.0".
+func Protocol(version string) CreateOption {
+	return func(c *createOptions) {
+		c.protocol = version
+	}
+}
+
 // CreatedAt can be passed to [Create] to provide a custom created at time.
 func CreatedAt(t time.Time) CreateOption {
 	return func(c *createOptions) {
@@ -178,6 +212,13 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at time. Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(o *createOptions) {
+		o.clock = c
+	}
+}
+
 // ExternalID can be passed to [Create] to provide a custom external id.
 func ExternalID(externalID string) CreateOption {
 	return func(c *createOptions) {
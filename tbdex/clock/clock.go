@@ -0,0 +1,60 @@
+// Package clock provides the source of the current time used by tbdex-go, e.g. for creation timestamps,
+// quote expiry, request token expiry and credential validity.
+//
+// Functions that create messages and resources accept a per call Clock option. Functions that accept a
+// context use the clock stored in the context with [NewContext], so that a single clock can be shared across
+// everything done on behalf of a request. When no clock is provided, [System] is used.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Func is an adapter that allows the use of ordinary functions as a [Clock].
+type Func func() time.Time
+
+// Now calls f().
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// System is the system clock.
+var System Clock = Func(time.Now)
+
+// Fixed returns a [Clock] that always returns t, which is useful in tests and when replaying historical exchanges.
+func Fixed(t time.Time) Clock {
+	return Func(func() time.Time { return t })
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the clock.
+func NewContext(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the clock carried by ctx, falling back to [System].
+func FromContext(ctx context.Context) Clock {
+	if ctx != nil {
+		if c, ok := ctx.Value(contextKey{}).(Clock); ok && c != nil {
+			return c
+		}
+	}
+
+	return System
+}
+
+// OrDefault returns c, falling back to [System] if c is nil.
+func OrDefault(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+
+	return c
+}
@@ -0,0 +1,25 @@
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFromContext(t *testing.T) {
+	assert.True(t, time.Since(clock.FromContext(context.Background()).Now()) < time.Minute)
+
+	fixed := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ctx := clock.NewContext(context.Background(), clock.Fixed(fixed))
+	assert.Equal(t, fixed, clock.FromContext(ctx).Now())
+}
+
+func TestOrDefault(t *testing.T) {
+	assert.True(t, time.Since(clock.OrDefault(nil).Now()) < time.Minute)
+
+	fixed := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, fixed, clock.OrDefault(clock.Fixed(fixed)).Now())
+}
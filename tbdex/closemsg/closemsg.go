@@ -1,12 +1,15 @@
 package closemsg
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/message"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"go.jetpack.io/typeid"
@@ -32,6 +35,11 @@ func (c Close) GetKind() string {
 	return c.Metadata.Kind
 }
 
+// GetSignature returns the signature of the message
+func (c Close) GetSignature() string {
+	return c.Signature
+}
+
 // GetValidNext returns the kinds of messages that can follow a close.
 func (c Close) GetValidNext() []string {
 	return []string{}
@@ -61,8 +69,13 @@ func (c Close) Digest() ([]byte, error) {
 }
 
 // Verify verifies the signature of the Close.
-func (c *Close) Verify() error {
-	decoded, err := crypto.VerifySignature(c, c.Signature)
+func (c Close) Verify() error {
+	return c.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [Close.Verify] but uses ctx when resolving the signer's DID.
+func (c Close) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, c, c.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify close signature: %w", err)
 	}
@@ -94,12 +107,17 @@ func (c *Close) UnmarshalJSON(data []byte) error {
 
 // Parse validates and unmarshals the input data into a Close.
 func Parse(data []byte) (Close, error) {
+	return ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Parse] but uses ctx when verifying the signature.
+func ParseContext(ctx context.Context, data []byte) (Close, error) {
 	c := Close{}
 	if err := json.Unmarshal(data, &c); err != nil {
 		return Close{}, fmt.Errorf("failed to unmarshal Close: %w", err)
 	}
 
-	if err := c.Verify(); err != nil {
+	if err := c.VerifyContext(ctx); err != nil {
 		return Close{}, fmt.Errorf("failed to verify Close: %w", err)
 	}
 
@@ -109,15 +127,23 @@ func Parse(data []byte) (Close, error) {
 // Create creates a new Close message.
 func Create(fromDID did.BearerDID, to, exchangeID string, opts ...CreateOption) (Close, error) {
 	o := createOptions{
-		id:        typeid.Must(typeid.WithPrefix(Kind)).String(),
-		createdAt: time.Now(),
-		protocol:  "1.0",
+		id:       typeid.Must(typeid.WithPrefix(Kind)).String(),
+		protocol: "1.0",
 	}
 
 	for _, opt := range opts {
 		opt(&o)
 	}
 
+	now := clock.OrDefault(o.clock).Now()
+	if o.createdAt.IsZero() {
+		o.createdAt = now
+	}
+
+	if !validator.SupportsProtocol(o.protocol) {
+		return Close{}, fmt.Errorf("unsupported protocol: %s", o.protocol)
+	}
+
 	c := Close{
 		Metadata: message.Metadata{
 			From:       fromDID.URI,
@@ -125,7 +151,7 @@ func Create(fromDID did.BearerDID, to, exchangeID string, opts ...CreateOption)
 			Kind:       Kind,
 			ID:         o.id,
 			ExchangeID: exchangeID,
-			CreatedAt:  o.createdAt.UTC().Format(time.RFC3339),
+			CreatedAt:  timestamp.New(o.createdAt),
 			ExternalID: o.externalID,
 			Protocol:   o.protocol,
 		},
@@ -149,6 +175,7 @@ type createOptions struct {
 	externalID string
 	reason     string
 	success    bool
+	clock      clock.Clock
 }
 
 // CreateOption defines a type for functions that can modify the createOptions struct.
@@ -161,6 +188,13 @@ func ID(id string) CreateOption {
 	}
 }
 
+// Protocol can be passed to [Create] to set the protocol version. Defaults to "1.0".
+func Protocol(version string) CreateOption {
+	return func(c *createOptions) {
+		c.protocol = version
+	}
+}
+
 // CreatedAt can be passed to [Create] to provide a custom created at time.
 func CreatedAt(t time.Time) CreateOption {
 	return func(c *createOptions) {
@@ -168,6 +202,13 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at time. Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(o *createOptions) {
+		o.clock = c
+	}
+}
+
 // ExternalID can be passed to [Create] to provide a custom external id.
 func ExternalID(externalID string) CreateOption {
 	return func(c *createOptions) {
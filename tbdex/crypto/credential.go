@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/tbd54566975/web5-go/vc"
+)
+
+// VerifyCredential decodes and verifies a VC-JWT. It performs the same checks as [vc.Verify] but resolves
+// the issuer's DID with the configured resolver.
+func VerifyCredential(vcJWT string, opts ...VerifyOption) (vc.DecodedVCJWT[vc.Claims], error) {
+	return VerifyCredentialContext(context.Background(), vcJWT, opts...)
+}
+
+// VerifyCredentialContext is like [VerifyCredential] but uses ctx when resolving the issuer's DID.
+func VerifyCredentialContext(ctx context.Context, vcJWT string, opts ...VerifyOption) (vc.DecodedVCJWT[vc.Claims], error) {
+	o := newVerifyOptions(opts)
+
+	decoded, err := vc.Decode[vc.Claims](vcJWT)
+	if err != nil {
+		return decoded, err
+	}
+
+	c := o.clock
+	if c == nil {
+		c = clock.FromContext(ctx)
+	}
+
+	if err := verifyCredentialFields(decoded, c.Now()); err != nil {
+		return decoded, err
+	}
+
+	// the kid is expected to be a DID URL of the issuer
+	if decoded.JWT.Claims.Issuer == "" || decoded.JWT.SignerDID.URI != decoded.JWT.Claims.Issuer {
+		return decoded, errors.New("JWT issuer does not match the did url provided as KID")
+	}
+
+	if _, err := VerifyJWSContext(ctx, vcJWT, WithResolver(o.resolver)); err != nil {
+		return decoded, fmt.Errorf("integrity check mismatch: %w", err)
+	}
+
+	return decoded, nil
+}
+
+func verifyCredentialFields(decoded vc.DecodedVCJWT[vc.Claims], now time.Time) error {
+	if decoded.JWT.Header.TYP != "JWT" {
+		return errors.New("invalid typ")
+	}
+
+	if decoded.VC.Issuer == "" {
+		return errors.New("missing issuer")
+	}
+
+	if decoded.VC.ID == "" {
+		return errors.New("missing id")
+	}
+
+	if decoded.VC.IssuanceDate == "" {
+		return errors.New("missing issuance date")
+	}
+
+	issuanceDate, err := time.Parse(time.RFC3339, decoded.VC.IssuanceDate)
+	if err != nil {
+		return fmt.Errorf("failed to parse issuance date: %w", err)
+	}
+
+	if now.Before(issuanceDate) {
+		return fmt.Errorf("vc cannot be used before %s", decoded.VC.IssuanceDate)
+	}
+
+	if decoded.VC.ExpirationDate != "" {
+		exp, err := time.Parse(time.RFC3339, decoded.VC.ExpirationDate)
+		if err != nil {
+			return fmt.Errorf("failed to parse expiration date: %w", err)
+		}
+
+		if now.After(exp) {
+			return fmt.Errorf("vc expired on %s", decoded.VC.ExpirationDate)
+		}
+	}
+
+	if !slices.Contains(decoded.VC.Type, vc.BaseType) {
+		return fmt.Errorf("missing base type: %s", vc.BaseType)
+	}
+
+	if !slices.Contains(decoded.VC.Context, vc.BaseContext) {
+		return fmt.Errorf("missing base @context: %s", vc.BaseContext)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package crypto_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
+	"github.com/TBD54566975/tbdex-go/tbdex/resolver"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/jws"
+	"github.com/tbd54566975/web5-go/jwt"
+	"github.com/tbd54566975/web5-go/vc"
+)
+
+// signCredential signs a VC-JWT with signer while claiming it was issued by issuer.
+func signCredential(t *testing.T, signer did.BearerDID, issuer string) string {
+	t.Helper()
+
+	credential := vc.Create(vc.Claims{"id": "did:example:subject"})
+	credential.Issuer = issuer
+
+	payload, err := json.Marshal(jwt.Claims{
+		Issuer:  issuer,
+		Subject: "did:example:subject",
+		Misc:    map[string]any{"vc": credential},
+	})
+	assert.NoError(t, err)
+
+	vcJWT, err := jws.Sign(payload, signer, jws.Type("JWT"))
+	assert.NoError(t, err)
+
+	return vcJWT
+}
+
+func TestVerifyCredential(t *testing.T) {
+	issuer := offlineDID(t, "did:example:issuer")
+	static := resolver.NewStatic(issuer.Document)
+
+	decoded, err := crypto.VerifyCredential(signCredential(t, issuer, issuer.URI), crypto.WithResolver(static))
+	assert.NoError(t, err)
+	assert.Equal(t, issuer.URI, decoded.VC.Issuer)
+}
+
+func TestVerifyCredential_IssuerPrefix(t *testing.T) {
+	attacker := offlineDID(t, "did:example:issuer.evil")
+	static := resolver.NewStatic(attacker.Document)
+
+	_, err := crypto.VerifyCredential(signCredential(t, attacker, "did:example:issuer"), crypto.WithResolver(static))
+	assert.Error(t, err)
+}
@@ -1,14 +1,19 @@
 package crypto
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/TBD54566975/tbdex-go/tbdex/resolver"
 	"github.com/gowebpki/jcs"
+	"github.com/tbd54566975/web5-go/crypto/dsa"
 	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/dids/didcore"
 	"github.com/tbd54566975/web5-go/jws"
 )
 
@@ -63,8 +68,50 @@ func VerifyDigest(expectedDigest string, payload any) error {
 	return nil
 }
 
+// VerifyOption implements functional options pattern for [VerifySignature], [VerifyJWS] and [VerifyCredential].
+type VerifyOption func(*verifyOptions)
+
+type verifyOptions struct {
+	resolver resolver.Resolver
+	payload  []byte
+	clock    clock.Clock
+}
+
+// WithResolver sets the resolver used to resolve the signer's DID. Defaults to [resolver.Default].
+func WithResolver(r resolver.Resolver) VerifyOption {
+	return func(o *verifyOptions) {
+		o.resolver = r
+	}
+}
+
+// WithClock sets the clock used to check the validity period of credentials. Defaults to the clock carried by
+// the context passed to [VerifyCredentialContext], see [clock.FromContext].
+func WithClock(c clock.Clock) VerifyOption {
+	return func(o *verifyOptions) {
+		o.clock = c
+	}
+}
+
+func newVerifyOptions(opts []VerifyOption) verifyOptions {
+	o := verifyOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.resolver == nil {
+		o.resolver = resolver.Default()
+	}
+
+	return o
+}
+
 // VerifySignature verifies the given signature and the signed payload
-func VerifySignature(digester Digester, signature string) (*jws.Decoded, error) {
+func VerifySignature(digester Digester, signature string, opts ...VerifyOption) (*jws.Decoded, error) {
+	return VerifySignatureContext(context.Background(), digester, signature, opts...)
+}
+
+// VerifySignatureContext is like [VerifySignature] but uses ctx when resolving the signer's DID.
+func VerifySignatureContext(ctx context.Context, digester Digester, signature string, opts ...VerifyOption) (*jws.Decoded, error) {
 	if signature == "" {
 		return nil, errors.New("could not verify signature because signature is empty")
 	}
@@ -74,10 +121,82 @@ func VerifySignature(digester Digester, signature string) (*jws.Decoded, error)
 		return nil, err
 	}
 
-	decoded, err := jws.Verify(signature, jws.Payload(payload))
+	decoded, err := VerifyJWSContext(ctx, signature, append([]VerifyOption{withPayload(payload)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
 
 	return &decoded, nil
 }
+
+// VerifyJWS verifies a compact JWS by resolving the DID Document of the signer identified by the kid
+// header and verifying the signature with the public key of the referenced verification method.
+func VerifyJWS(compactJWS string, opts ...VerifyOption) (jws.Decoded, error) {
+	return VerifyJWSContext(context.Background(), compactJWS, opts...)
+}
+
+// VerifyJWSContext is like [VerifyJWS] but uses ctx when resolving the signer's DID.
+func VerifyJWSContext(ctx context.Context, compactJWS string, opts ...VerifyOption) (jws.Decoded, error) {
+	o := newVerifyOptions(opts)
+
+	var decodeOpts []jws.DecodeOption
+	if o.payload != nil {
+		decodeOpts = append(decodeOpts, jws.Payload(o.payload))
+	}
+
+	decoded, err := jws.Decode(compactJWS, decodeOpts...)
+	if err != nil {
+		return decoded, fmt.Errorf("signature verification failed: %w", err)
+	}
+
+	if err := verifyDecodedJWS(ctx, decoded, o.resolver); err != nil {
+		return decoded, err
+	}
+
+	return decoded, nil
+}
+
+// withPayload provides the detached payload of the JWS being verified.
+func withPayload(payload []byte) VerifyOption {
+	return func(o *verifyOptions) {
+		o.payload = payload
+	}
+}
+
+func verifyDecodedJWS(ctx context.Context, decoded jws.Decoded, r resolver.Resolver) error {
+	if decoded.Header.ALG == "" || decoded.Header.KID == "" {
+		return errors.New("malformed JWS header. alg and kid are required")
+	}
+
+	signerDID, err := did.Parse(decoded.Header.KID)
+	if err != nil {
+		return errors.New("malformed JWS header. kid must be a DID URL")
+	}
+
+	result, err := r.Resolve(ctx, signerDID.URI)
+	if err != nil {
+		return fmt.Errorf("failed to resolve DID: %w", err)
+	}
+
+	vm, err := result.Document.SelectVerificationMethod(didcore.ID(signerDID.URL))
+	if err != nil {
+		return fmt.Errorf("kid does not match any verification method %w", err)
+	}
+
+	if vm.PublicKeyJwk == nil {
+		return errors.New("verification method does not contain a public key")
+	}
+
+	toVerify := decoded.Parts[0] + "." + decoded.Parts[1]
+
+	verified, err := dsa.Verify([]byte(toVerify), decoded.Signature, *vm.PublicKeyJwk)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+
+	if !verified {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
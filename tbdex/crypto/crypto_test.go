@@ -0,0 +1,62 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
+	"github.com/TBD54566975/tbdex-go/tbdex/resolver"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/dids/didcore"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+type payload map[string]any
+
+func (p payload) Digest() ([]byte, error) {
+	return crypto.DigestJSON(p)
+}
+
+// offlineDID returns a bearer DID with the given uri whose method cannot be resolved by the default resolver
+func offlineDID(t *testing.T, uri string) did.BearerDID {
+	t.Helper()
+
+	bearerDID, err := didjwk.Create()
+	assert.NoError(t, err)
+
+	vm := bearerDID.Document.VerificationMethod[0]
+	vm.ID = uri + "#0"
+	vm.Controller = uri
+
+	bearerDID.DID, err = did.Parse(uri)
+	assert.NoError(t, err)
+	bearerDID.Document = didcore.Document{ID: uri, VerificationMethod: []didcore.VerificationMethod{vm}}
+
+	return bearerDID
+}
+
+func TestVerifySignature_Resolver(t *testing.T) {
+	bearerDID := offlineDID(t, "did:example:alice")
+	p := payload{"hello": "world"}
+
+	signature, err := crypto.Sign(p, bearerDID)
+	assert.NoError(t, err)
+
+	_, err = crypto.VerifySignature(p, signature)
+	assert.Error(t, err)
+
+	static := resolver.NewStatic(bearerDID.Document)
+
+	decoded, err := crypto.VerifySignature(p, signature, crypto.WithResolver(static))
+	assert.NoError(t, err)
+	assert.Equal(t, bearerDID.URI, decoded.SignerDID.URI)
+
+	_, err = crypto.VerifySignature(payload{"hello": "mars"}, signature, crypto.WithResolver(static))
+	assert.Error(t, err)
+
+	resolver.SetDefault(static)
+	defer resolver.SetDefault(nil)
+
+	_, err = crypto.VerifySignature(p, signature)
+	assert.NoError(t, err)
+}
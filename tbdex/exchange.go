@@ -0,0 +1,250 @@
+package tbdex
+
+import (
+	"errors"
+	"fmt"
+
+	libcancel "github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	libclose "github.com/TBD54566975/tbdex-go/tbdex/closemsg"
+	liborder "github.com/TBD54566975/tbdex-go/tbdex/order"
+	liborderinstructions "github.com/TBD54566975/tbdex-go/tbdex/orderinstructions"
+	liborderstatus "github.com/TBD54566975/tbdex-go/tbdex/orderstatus"
+	libquote "github.com/TBD54566975/tbdex-go/tbdex/quote"
+	librfq "github.com/TBD54566975/tbdex-go/tbdex/rfq"
+)
+
+// Exchange tracks the messages of a single tbdex exchange and enforces the order in which they can be added.
+// An Exchange always starts with an RFQ. Every subsequent message must:
+//   - share the RFQ's exchange id
+//   - be a valid next message kind for the latest message in the exchange
+//   - be sent by the customer (RFQ sender) or the PFI (RFQ recipient) depending on its kind
+//
+// No messages can be added once the exchange has been closed.
+//
+// The zero value is an empty exchange ready to use.
+type Exchange struct {
+	messages          []Message
+	rfq               *librfq.RFQ
+	quote             *libquote.Quote
+	order             *liborder.Order
+	orderInstructions *liborderinstructions.OrderInstructions
+	orderStatuses     []liborderstatus.OrderStatus
+	cancel            *libcancel.Cancel
+	close             *libclose.Close
+}
+
+// NewExchange creates an [Exchange] and adds the provided messages to it in order.
+func NewExchange(messages ...Message) (*Exchange, error) {
+	e := &Exchange{}
+	for _, m := range messages {
+		if err := e.Add(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// Add validates that the message can follow the latest message in the exchange and appends it.
+func (e *Exchange) Add(m Message) error {
+	if m == nil {
+		return errors.New("cannot add nil message to exchange")
+	}
+
+	// the typed message is tracked while m itself is retained, so that raw messages are kept verbatim
+	typed := unwrapMessage(m)
+	if typed == nil {
+		return errors.New("cannot add nil message to exchange")
+	}
+
+	kind := m.GetKind()
+	metadata := m.GetMetadata()
+
+	if e.rfq == nil {
+		rfq, ok := typed.(librfq.RFQ)
+		if !ok {
+			return fmt.Errorf("exchange must start with an rfq, got: %s", kind)
+		}
+
+		e.rfq = &rfq
+		e.messages = append(e.messages, m)
+
+		return nil
+	}
+
+	if e.close != nil {
+		return fmt.Errorf("exchange %s is closed, cannot add %s", e.ID(), kind)
+	}
+
+	if metadata.ExchangeID != e.ID() {
+		return fmt.Errorf("%s exchange id: %s does not match exchange: %s", kind, metadata.ExchangeID, e.ID())
+	}
+
+	if metadata.Protocol != e.Protocol() {
+		return fmt.Errorf("%s protocol: %s does not match exchange protocol: %s", kind, metadata.Protocol, e.Protocol())
+	}
+
+	latest := e.Latest()
+	if !latest.IsValidNext(kind) {
+		return fmt.Errorf("%s is not a valid next message after %s. valid next: %v", kind, latest.GetKind(), latest.GetValidNext())
+	}
+
+	from, to := e.PFI(), e.Customer()
+	if isCustomerMessage(kind) {
+		from, to = e.Customer(), e.PFI()
+	}
+
+	if metadata.From != from {
+		return fmt.Errorf("%s must be sent from %s, got: %s", kind, from, metadata.From)
+	}
+
+	if metadata.To != to {
+		return fmt.Errorf("%s must be sent to %s, got: %s", kind, to, metadata.To)
+	}
+
+	switch msg := typed.(type) {
+	case libquote.Quote:
+		e.quote = &msg
+	case liborder.Order:
+		e.order = &msg
+	case liborderinstructions.OrderInstructions:
+		e.orderInstructions = &msg
+	case liborderstatus.OrderStatus:
+		e.orderStatuses = append(e.orderStatuses, msg)
+	case libcancel.Cancel:
+		e.cancel = &msg
+	case libclose.Close:
+		e.close = &msg
+	default:
+		return fmt.Errorf("unsupported message kind: %s", kind)
+	}
+
+	e.messages = append(e.messages, m)
+
+	return nil
+}
+
+// ID returns the id of the exchange. Empty if no RFQ has been added yet.
+func (e *Exchange) ID() string {
+	if e.rfq == nil {
+		return ""
+	}
+
+	return e.rfq.Metadata.ExchangeID
+}
+
+// Protocol returns the protocol version of the exchange i.e. that of the RFQ. Empty if no RFQ has been
+// added yet. All messages in an exchange must share the same protocol version.
+func (e *Exchange) Protocol() string {
+	if e.rfq == nil {
+		return ""
+	}
+
+	return e.rfq.Metadata.Protocol
+}
+
+// Customer returns the DID of the customer i.e. the sender of the RFQ.
+func (e *Exchange) Customer() string {
+	if e.rfq == nil {
+		return ""
+	}
+
+	return e.rfq.Metadata.From
+}
+
+// PFI returns the DID of the PFI i.e. the recipient of the RFQ.
+func (e *Exchange) PFI() string {
+	if e.rfq == nil {
+		return ""
+	}
+
+	return e.rfq.Metadata.To
+}
+
+// State returns the kind of the latest message in the exchange. Empty if no messages have been added yet.
+func (e *Exchange) State() string {
+	latest := e.Latest()
+	if latest == nil {
+		return ""
+	}
+
+	return latest.GetKind()
+}
+
+// Latest returns the latest message in the exchange. nil if no messages have been added yet.
+func (e *Exchange) Latest() Message {
+	if len(e.messages) == 0 {
+		return nil
+	}
+
+	return e.messages[len(e.messages)-1]
+}
+
+// IsValidNext checks if a message of the given kind can be added to the exchange.
+func (e *Exchange) IsValidNext(kind string) bool {
+	if e.close != nil {
+		return false
+	}
+
+	latest := e.Latest()
+	if latest == nil {
+		return kind == librfq.Kind
+	}
+
+	return latest.IsValidNext(kind)
+}
+
+// IsClosed returns true if the exchange has been closed.
+func (e *Exchange) IsClosed() bool {
+	return e.close != nil
+}
+
+// Messages returns all messages in the exchange in the order they were added.
+func (e *Exchange) Messages() []Message {
+	return append([]Message(nil), e.messages...)
+}
+
+// RFQ returns the exchange's RFQ. nil if no RFQ has been added yet.
+func (e *Exchange) RFQ() *librfq.RFQ {
+	return e.rfq
+}
+
+// Quote returns the latest quote in the exchange. nil if no quote has been added yet.
+func (e *Exchange) Quote() *libquote.Quote {
+	return e.quote
+}
+
+// Order returns the exchange's order. nil if no order has been added yet.
+func (e *Exchange) Order() *liborder.Order {
+	return e.order
+}
+
+// OrderInstructions returns the exchange's order instructions. nil if none have been added yet.
+func (e *Exchange) OrderInstructions() *liborderinstructions.OrderInstructions {
+	return e.orderInstructions
+}
+
+// OrderStatuses returns all order statuses in the exchange in the order they were added.
+func (e *Exchange) OrderStatuses() []liborderstatus.OrderStatus {
+	return append([]liborderstatus.OrderStatus(nil), e.orderStatuses...)
+}
+
+// Cancel returns the exchange's cancel. nil if the customer has not cancelled the exchange.
+func (e *Exchange) Cancel() *libcancel.Cancel {
+	return e.cancel
+}
+
+// Close returns the exchange's close. nil if the exchange has not been closed.
+func (e *Exchange) Close() *libclose.Close {
+	return e.close
+}
+
+// isCustomerMessage returns true if the message kind is sent by the customer rather than the PFI.
+func isCustomerMessage(kind string) bool {
+	switch kind {
+	case librfq.Kind, liborder.Kind, libcancel.Kind:
+		return true
+	default:
+		return false
+	}
+}
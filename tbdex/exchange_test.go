@@ -0,0 +1,208 @@
+package tbdex_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/orderinstructions"
+	"github.com/TBD54566975/tbdex-go/tbdex/orderstatus"
+	"github.com/TBD54566975/tbdex-go/tbdex/quote"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/alecthomas/assert/v2"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func createRFQ(t *testing.T, walletDID, pfiDID did.BearerDID) rfq.RFQ {
+	t.Helper()
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+	)
+	assert.NoError(t, err)
+
+	return r
+}
+
+func createQuote(t *testing.T, pfiDID did.BearerDID, to, exchangeID string) quote.Quote {
+	t.Helper()
+
+	q, err := quote.Create(
+		pfiDID,
+		to,
+		exchangeID,
+		time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+
+	return q
+}
+
+func TestExchange(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+	exchangeID := r.Metadata.ExchangeID
+
+	q := createQuote(t, pfiDID, walletDID.URI, exchangeID)
+
+	o, err := order.Create(walletDID, pfiDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	oi, err := orderinstructions.Create(pfiDID, walletDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	os1, err := orderstatus.Create(pfiDID, walletDID.URI, exchangeID, orderstatus.PAYIN_INITIATED)
+	assert.NoError(t, err)
+
+	os2, err := orderstatus.Create(pfiDID, walletDID.URI, exchangeID, orderstatus.PAYOUT_SETTLED)
+	assert.NoError(t, err)
+
+	c, err := closemsg.Create(pfiDID, walletDID.URI, exchangeID, closemsg.Success(true))
+	assert.NoError(t, err)
+
+	exchange, err := tbdex.NewExchange(r, q, o, oi, os1, os2, c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, exchangeID, exchange.ID())
+	assert.Equal(t, closemsg.Kind, exchange.State())
+	assert.True(t, exchange.IsClosed())
+	assert.Equal(t, 7, len(exchange.Messages()))
+	assert.Equal(t, r.Metadata.ID, exchange.RFQ().Metadata.ID)
+	assert.Equal(t, q.Metadata.ID, exchange.Quote().Metadata.ID)
+	assert.Equal(t, o.Metadata.ID, exchange.Order().Metadata.ID)
+	assert.Equal(t, oi.Metadata.ID, exchange.OrderInstructions().Metadata.ID)
+	assert.Equal(t, 2, len(exchange.OrderStatuses()))
+	assert.Equal(t, walletDID.URI, exchange.Customer())
+	assert.Equal(t, pfiDID.URI, exchange.PFI())
+}
+
+func TestExchange_MustStartWithRFQ(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	q := createQuote(t, pfiDID, walletDID.URI, "rfq_01hwztehxhe139magy0a18mzms")
+
+	var exchange tbdex.Exchange
+	err := exchange.Add(q)
+	assert.Error(t, err)
+	assert.Equal(t, "", exchange.State())
+}
+
+func TestExchange_InvalidNext(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+
+	o, err := order.Create(walletDID, pfiDID.URI, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+
+	exchange, err := tbdex.NewExchange(r)
+	assert.NoError(t, err)
+
+	err = exchange.Add(o)
+	assert.Error(t, err)
+	assert.Equal(t, rfq.Kind, exchange.State())
+	assert.False(t, exchange.IsValidNext(order.Kind))
+	assert.True(t, exchange.IsValidNext(quote.Kind))
+}
+
+func TestExchange_ExchangeIDMismatch(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+	q := createQuote(t, pfiDID, walletDID.URI, "rfq_01hwztehxhe139magy0a18mzms")
+
+	exchange, err := tbdex.NewExchange(r)
+	assert.NoError(t, err)
+
+	err = exchange.Add(q)
+	assert.Error(t, err)
+	assert.Zero(t, exchange.Quote())
+}
+
+func TestExchange_ProtocolMismatch(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+
+	q, err := quote.Create(
+		pfiDID,
+		walletDID.URI,
+		r.Metadata.ExchangeID,
+		time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+
+	// only protocol 1.0 is supported, so the quote is tampered with rather than created with another version
+	q.Metadata.Protocol = "2.0"
+
+	exchange, err := tbdex.NewExchange(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0", exchange.Protocol())
+
+	err = exchange.Add(q)
+	assert.Error(t, err)
+	assert.Zero(t, exchange.Quote())
+}
+
+func TestExchange_WrongDirection(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+
+	// quote sent by the customer rather than the PFI
+	q := createQuote(t, walletDID, pfiDID.URI, r.Metadata.ExchangeID)
+
+	exchange, err := tbdex.NewExchange(r)
+	assert.NoError(t, err)
+
+	err = exchange.Add(q)
+	assert.Error(t, err)
+
+	// cancel sent by the PFI rather than the customer
+	c, err := cancel.Create(pfiDID, walletDID.URI, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+
+	err = exchange.Add(c)
+	assert.Error(t, err)
+}
+
+func TestExchange_Closed(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+
+	c, err := closemsg.Create(pfiDID, walletDID.URI, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+
+	exchange, err := tbdex.NewExchange(r, c)
+	assert.NoError(t, err)
+
+	q := createQuote(t, pfiDID, walletDID.URI, r.Metadata.ExchangeID)
+
+	err = exchange.Add(q)
+	assert.Error(t, err)
+	assert.False(t, exchange.IsValidNext(quote.Kind))
+}
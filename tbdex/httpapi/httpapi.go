@@ -0,0 +1,28 @@
+// Package httpapi defines the wire types of the [tbDEX HTTP API] that are shared by the PFI side implemented by
+// package httpserver and the customer side implemented by package httpclient.
+//
+// [tbDEX HTTP API]: https://github.com/TBD54566975/tbdex/tree/main/specs/http-api
+package httpapi
+
+// ErrorResponse is the body returned by a PFI when a request fails.
+//
+// [ErrorResponse]: https://github.com/TBD54566975/tbdex/tree/main/specs/http-api#error-responses
+type ErrorResponse struct {
+	Errors []ErrorDetail `json:"errors"`
+}
+
+// ErrorDetail describes a single problem encountered while processing a request.
+type ErrorDetail struct {
+	ID     string         `json:"id,omitempty"`
+	Title  string         `json:"title,omitempty"`
+	Detail string         `json:"detail"`
+	Source *ErrorSource   `json:"source,omitempty"`
+	Meta   map[string]any `json:"meta,omitempty"`
+}
+
+// ErrorSource points to the part of the request that caused the error.
+type ErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+	Header    string `json:"header,omitempty"`
+}
@@ -0,0 +1,385 @@
+// Package httpclient implements the customer side of the [tbDEX HTTP API]. It resolves a PFI's service endpoint
+// from its DID Document and verifies the integrity of every message and resource returned by the PFI.
+//
+// [tbDEX HTTP API]: https://github.com/TBD54566975/tbdex/tree/main/specs/http-api
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/auth"
+	"github.com/TBD54566975/tbdex-go/tbdex/balance"
+	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/TBD54566975/tbdex-go/tbdex/httpapi"
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/resolver"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/tbd54566975/web5-go/dids/did"
+)
+
+// pfiServiceType is the DID Document service type used by PFIs to advertise their tbDEX HTTP API.
+const pfiServiceType = "PFI"
+
+// maxResponseSize is the maximum size of a response body read by the client.
+const maxResponseSize = 10 << 20
+
+// EndpointResolver returns the base URL of the tbDEX HTTP API hosted by the given PFI.
+type EndpointResolver func(ctx context.Context, pfiDID string) (string, error)
+
+// Client sends requests to PFIs.
+type Client struct {
+	httpClient      *http.Client
+	resolveEndpoint EndpointResolver
+}
+
+// Option implements functional options pattern for [New].
+type Option func(*Client)
+
+// HTTPClient can be passed to [New] to provide a custom [http.Client]. Defaults to [http.DefaultClient].
+func HTTPClient(c *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = c
+	}
+}
+
+// ResolveEndpoint can be passed to [New] to override how a PFI's service endpoint is determined.
+// Defaults to resolving the PFI's DID and selecting the first endpoint of its "PFI" service.
+func ResolveEndpoint(resolver EndpointResolver) Option {
+	return func(client *Client) {
+		client.resolveEndpoint = resolver
+	}
+}
+
+// New creates a [Client].
+func New(opts ...Option) *Client {
+	c := &Client{
+		httpClient:      http.DefaultClient,
+		resolveEndpoint: resolvePFIServiceEndpoint,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ResponseError is returned when a PFI responds with a non-successful status code.
+type ResponseError struct {
+	StatusCode int
+	Errors     []httpapi.ErrorDetail
+}
+
+func (e *ResponseError) Error() string {
+	details := make([]string, 0, len(e.Errors))
+	for _, d := range e.Errors {
+		details = append(details, d.Detail)
+	}
+
+	return fmt.Sprintf("pfi responded with %d: %s", e.StatusCode, strings.Join(details, "; "))
+}
+
+// GetOfferings fetches and verifies the offerings published by the given PFI.
+func (c *Client) GetOfferings(ctx context.Context, pfiDID string) ([]offering.Offering, error) {
+	var data []json.RawMessage
+	if err := c.do(ctx, http.MethodGet, pfiDID, "/offerings", nil, "", &data); err != nil {
+		return nil, fmt.Errorf("failed to get offerings: %w", err)
+	}
+
+	offerings := make([]offering.Offering, 0, len(data))
+	for _, raw := range data {
+		var o offering.Offering
+		if err := o.ParseContext(ctx, raw); err != nil {
+			return nil, fmt.Errorf("failed to parse offering: %w", err)
+		}
+
+		if o.Metadata.From != pfiDID {
+			return nil, fmt.Errorf("offering %s was not published by %s", o.Metadata.ID, pfiDID)
+		}
+
+		offerings = append(offerings, o)
+	}
+
+	return offerings, nil
+}
+
+type createExchangeOptions struct {
+	replyTo string
+}
+
+// CreateExchangeOption implements functional options pattern for [Client.CreateExchange].
+type CreateExchangeOption func(*createExchangeOptions)
+
+// ReplyTo can be passed to [Client.CreateExchange] to provide a URL the PFI should send subsequent messages to.
+func ReplyTo(url string) CreateExchangeOption {
+	return func(o *createExchangeOptions) {
+		o.replyTo = url
+	}
+}
+
+// CreateExchange submits an RFQ to the PFI it is addressed to, creating a new exchange.
+func (c *Client) CreateExchange(ctx context.Context, r rfq.RFQ, opts ...CreateExchangeOption) error {
+	o := createExchangeOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	body := map[string]any{"message": r}
+	if o.replyTo != "" {
+		body["replyTo"] = o.replyTo
+	}
+
+	if err := c.do(ctx, http.MethodPost, r.Metadata.To, "/exchanges", body, "", nil); err != nil {
+		return fmt.Errorf("failed to create exchange: %w", err)
+	}
+
+	return nil
+}
+
+// SubmitOrder submits an order to the PFI it is addressed to.
+func (c *Client) SubmitOrder(ctx context.Context, o order.Order) error {
+	path := "/exchanges/" + url.PathEscape(o.Metadata.ExchangeID)
+	if err := c.do(ctx, http.MethodPut, o.Metadata.To, path, map[string]any{"message": o}, "", nil); err != nil {
+		return fmt.Errorf("failed to submit order: %w", err)
+	}
+
+	return nil
+}
+
+// SubmitCancel submits a cancel to the PFI it is addressed to.
+func (c *Client) SubmitCancel(ctx context.Context, cl cancel.Cancel) error {
+	path := "/exchanges/" + url.PathEscape(cl.Metadata.ExchangeID)
+	if err := c.do(ctx, http.MethodPut, cl.Metadata.To, path, map[string]any{"message": cl}, "", nil); err != nil {
+		return fmt.Errorf("failed to submit cancel: %w", err)
+	}
+
+	return nil
+}
+
+// GetExchange fetches all messages of an exchange on behalf of the requester. Every message is parsed and
+// verified, and the messages must form a valid [tbdex.Exchange] with the given id whose RFQ was sent by the
+// requester to the PFI.
+func (c *Client) GetExchange(ctx context.Context, requester did.BearerDID, pfiDID, exchangeID string) (*tbdex.Exchange, error) {
+	token, err := auth.CreateRequestToken(requester, pfiDID, auth.Clock(clock.FromContext(ctx)))
+	if err != nil {
+		return nil, err
+	}
+
+	var data []json.RawMessage
+	if err := c.do(ctx, http.MethodGet, pfiDID, "/exchanges/"+url.PathEscape(exchangeID), nil, token, &data); err != nil {
+		return nil, fmt.Errorf("failed to get exchange: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("pfi returned no messages for exchange %s", exchangeID)
+	}
+
+	exchange := &tbdex.Exchange{}
+	for _, raw := range data {
+		msg, err := tbdex.ParseMessageContext(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse message in exchange %s: %w", exchangeID, err)
+		}
+
+		if err := exchange.Add(msg); err != nil {
+			return nil, fmt.Errorf("invalid exchange %s: %w", exchangeID, err)
+		}
+	}
+
+	if exchange.ID() != exchangeID {
+		return nil, fmt.Errorf("pfi returned exchange %s instead of %s", exchange.ID(), exchangeID)
+	}
+
+	if to := exchange.RFQ().Metadata.To; to != pfiDID {
+		return nil, fmt.Errorf("exchange %s was created with %s, not %s", exchangeID, to, pfiDID)
+	}
+
+	if from := exchange.RFQ().Metadata.From; from != requester.URI {
+		return nil, fmt.Errorf("exchange %s was created by %s, not %s", exchangeID, from, requester.URI)
+	}
+
+	return exchange, nil
+}
+
+type getExchangesOptions struct {
+	offset int
+	limit  int
+}
+
+// GetExchangesOption implements functional options pattern for [Client.GetExchanges].
+type GetExchangesOption func(*getExchangesOptions)
+
+// Page can be passed to [Client.GetExchanges] to request a single page of results.
+func Page(offset, limit int) GetExchangesOption {
+	return func(o *getExchangesOptions) {
+		o.offset = offset
+		o.limit = limit
+	}
+}
+
+// GetExchanges fetches the ids of the requester's exchanges with the given PFI.
+func (c *Client) GetExchanges(ctx context.Context, requester did.BearerDID, pfiDID string, opts ...GetExchangesOption) ([]string, error) {
+	o := getExchangesOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	token, err := auth.CreateRequestToken(requester, pfiDID, auth.Clock(clock.FromContext(ctx)))
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if o.offset > 0 {
+		query.Set("page[offset]", strconv.Itoa(o.offset))
+	}
+
+	if o.limit > 0 {
+		query.Set("page[limit]", strconv.Itoa(o.limit))
+	}
+
+	path := "/exchanges"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var ids []string
+	if err := c.do(ctx, http.MethodGet, pfiDID, path, nil, token, &ids); err != nil {
+		return nil, fmt.Errorf("failed to get exchanges: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetBalances fetches and verifies the requester's balances held by the given PFI.
+func (c *Client) GetBalances(ctx context.Context, requester did.BearerDID, pfiDID string) ([]balance.Balance, error) {
+	token, err := auth.CreateRequestToken(requester, pfiDID, auth.Clock(clock.FromContext(ctx)))
+	if err != nil {
+		return nil, err
+	}
+
+	var data []json.RawMessage
+	if err := c.do(ctx, http.MethodGet, pfiDID, "/balances", nil, token, &data); err != nil {
+		return nil, fmt.Errorf("failed to get balances: %w", err)
+	}
+
+	balances := make([]balance.Balance, 0, len(data))
+	for _, raw := range data {
+		b, err := balance.ParseContext(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse balance: %w", err)
+		}
+
+		if b.Metadata.From != pfiDID {
+			return nil, fmt.Errorf("balance %s was not published by %s", b.Metadata.ID, pfiDID)
+		}
+
+		balances = append(balances, b)
+	}
+
+	return balances, nil
+}
+
+// do sends a request to the PFI and decodes the response's data into out if provided.
+func (c *Client) do(ctx context.Context, method, pfiDID, path string, body any, token string, out any) error {
+	endpoint, err := c.resolveEndpoint(ctx, pfiDID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve pfi service endpoint: %w", err)
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to JSON marshal request body: %w", err)
+		}
+
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(endpoint, "/")+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// one byte past the limit is read to tell a body of exactly maxResponseSize apart from a larger one
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if len(respBody) > maxResponseSize {
+		return fmt.Errorf("response body exceeds %d bytes", maxResponseSize)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respErr := &ResponseError{StatusCode: resp.StatusCode}
+
+		var errResp httpapi.ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err == nil {
+			respErr.Errors = errResp.Errors
+		}
+
+		return respErr
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	var data struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return fmt.Errorf("failed to JSON unmarshal response body: %w", err)
+	}
+
+	if err := json.Unmarshal(data.Data, out); err != nil {
+		return fmt.Errorf("failed to JSON unmarshal response data: %w", err)
+	}
+
+	return nil
+}
+
+// resolvePFIServiceEndpoint resolves the PFI's DID and returns the first endpoint of its "PFI" service.
+func resolvePFIServiceEndpoint(ctx context.Context, pfiDID string) (string, error) {
+	result, err := resolver.Default().Resolve(ctx, pfiDID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve pfi did: %w", err)
+	}
+
+	for _, service := range result.Document.Service {
+		if service.Type == pfiServiceType && len(service.ServiceEndpoint) > 0 {
+			return service.ServiceEndpoint[0], nil
+		}
+	}
+
+	return "", errors.New("pfi did document does not contain a PFI service endpoint")
+}
@@ -0,0 +1,224 @@
+package httpclient_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/balance"
+	"github.com/TBD54566975/tbdex-go/tbdex/httpclient"
+	"github.com/TBD54566975/tbdex-go/tbdex/httpserver"
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/quote"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/store"
+	"github.com/alecthomas/assert/v2"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+// newClient creates a client for a PFI whose API is served by handler.
+func newClient(t *testing.T, handler http.Handler) *httpclient.Client {
+	t.Helper()
+
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	return httpclient.New(
+		httpclient.HTTPClient(ts.Client()),
+		httpclient.ResolveEndpoint(func(ctx context.Context, pfiDID string) (string, error) {
+			return ts.URL, nil
+		}),
+	)
+}
+
+func TestGetOfferings(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	otherDID, _ := didjwk.Create()
+
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")}),
+		offering.NewPayout("MXN", []offering.PayoutMethod{offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour)}),
+		"16.665",
+		offering.NewCancellationDetails(false),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+
+	client := newClient(t, httpserver.New(
+		pfiDID.URI,
+		httpserver.OnGetOfferings(func(ctx context.Context) ([]offering.Offering, error) {
+			return []offering.Offering{o}, nil
+		}),
+	))
+
+	offerings, err := client.GetOfferings(context.Background(), pfiDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(offerings))
+	assert.Equal(t, o.Metadata.ID, offerings[0].Metadata.ID)
+
+	// the offering was not published by the PFI the client asked
+	_, err = client.GetOfferings(context.Background(), otherDID.URI)
+	assert.Error(t, err)
+}
+
+func TestExchangeLifecycle(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	ctx := context.Background()
+
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")}),
+		offering.NewPayout("MXN", []offering.PayoutMethod{offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour)}),
+		"16.665",
+		offering.NewCancellationDetails(false),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+
+	memory := store.NewMemory()
+	assert.NoError(t, memory.PutOffering(ctx, o))
+
+	client := newClient(t, httpserver.New(
+		pfiDID.URI,
+		httpserver.OfferingsStore(memory),
+		httpserver.ExchangesStore(memory),
+	))
+
+	r, err := rfq.Create(walletDID, pfiDID.URI, o.Metadata.ID, rfq.Payin("100", "STORED_BALANCE"), rfq.Payout("BANK_ACCOUNT"))
+	assert.NoError(t, err)
+
+	err = client.CreateExchange(ctx, r, httpclient.ReplyTo("https://wallet.example/callback"))
+	assert.NoError(t, err)
+
+	q, err := quote.Create(
+		pfiDID,
+		walletDID.URI,
+		r.Metadata.ExchangeID,
+		time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, memory.AddMessage(ctx, q))
+
+	ord, err := order.Create(walletDID, pfiDID.URI, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+
+	err = client.SubmitOrder(ctx, ord)
+	assert.NoError(t, err)
+
+	exchange, err := client.GetExchange(ctx, walletDID, pfiDID.URI, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+	assert.Equal(t, order.Kind, exchange.State())
+	assert.NotZero(t, exchange.Quote())
+
+	ids, err := client.GetExchanges(ctx, walletDID, pfiDID.URI, httpclient.Page(0, 10))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{r.Metadata.ExchangeID}, ids)
+}
+
+func TestGetExchange_Mismatch(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	otherDID, _ := didjwk.Create()
+
+	createRFQ := func(from did.BearerDID, to string) rfq.RFQ {
+		r, err := rfq.Create(
+			from,
+			to,
+			"offering_01hwztehxdezgajyyc95te7vbw",
+			rfq.Payin("100", "STORED_BALANCE"),
+			rfq.Payout("BANK_ACCOUNT"),
+		)
+		assert.NoError(t, err)
+
+		return r
+	}
+
+	requested := createRFQ(walletDID, pfiDID.URI)
+	toOtherPFI := createRFQ(walletDID, otherDID.URI)
+	fromOtherCustomer := createRFQ(otherDID, pfiDID.URI)
+
+	tests := []struct {
+		name       string
+		exchangeID string
+		messages   []tbdex.Message
+		err        string
+	}{
+		{"empty", requested.Metadata.ExchangeID, []tbdex.Message{}, "no messages"},
+		{"other exchange", requested.Metadata.ExchangeID, []tbdex.Message{toOtherPFI}, "instead of"},
+		{"other pfi", toOtherPFI.Metadata.ExchangeID, []tbdex.Message{toOtherPFI}, "created with"},
+		{"other customer", fromOtherCustomer.Metadata.ExchangeID, []tbdex.Message{fromOtherCustomer}, "created by"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]any{"data": tt.messages})
+			}))
+
+			_, err := client.GetExchange(context.Background(), walletDID, pfiDID.URI, tt.exchangeID)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestSubmitOrder_Error(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	client := newClient(t, httpserver.New(pfiDID.URI, httpserver.ExchangesStore(store.NewMemory())))
+
+	o, err := order.Create(walletDID, pfiDID.URI, "rfq_01hwztehxhe139magy0a18mzms")
+	assert.NoError(t, err)
+
+	err = client.SubmitOrder(context.Background(), o)
+
+	var respErr *httpclient.ResponseError
+	assert.True(t, errors.As(err, &respErr))
+	assert.Equal(t, http.StatusNotFound, respErr.StatusCode)
+	assert.NotZero(t, respErr.Errors)
+}
+
+func TestGetBalances(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	bal, err := balance.Create(pfiDID, "USD", "100")
+	assert.NoError(t, err)
+
+	client := newClient(t, httpserver.New(
+		pfiDID.URI,
+		httpserver.OnGetBalances(func(ctx context.Context, requester string) ([]balance.Balance, error) {
+			return []balance.Balance{bal}, nil
+		}),
+	))
+
+	balances, err := client.GetBalances(context.Background(), walletDID, pfiDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(balances))
+	assert.Equal(t, "USD", balances[0].Data.CurrencyCode)
+}
+
+func TestResponseTooLarge(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+
+	client := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte(" "), 10<<20+1))
+	}))
+
+	_, err := client.GetOfferings(context.Background(), pfiDID.URI)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "response body exceeds")
+}
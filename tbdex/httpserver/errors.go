@@ -0,0 +1,106 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/httpapi"
+	"github.com/TBD54566975/tbdex-go/tbdex/validator"
+)
+
+// ErrorResponse is the body returned by the server when a request fails. See [httpapi.ErrorResponse].
+type ErrorResponse = httpapi.ErrorResponse
+
+// ErrorDetail describes a single problem encountered while processing a request. See [httpapi.ErrorDetail].
+type ErrorDetail = httpapi.ErrorDetail
+
+// ErrorSource points to the part of the request that caused the error. See [httpapi.ErrorSource].
+type ErrorSource = httpapi.ErrorSource
+
+// Error can be returned by callbacks in order to control the status code and error details
+// sent back to the client. Any other error returned by a callback results in a 500.
+type Error struct {
+	StatusCode int
+	Details    []ErrorDetail
+}
+
+// NewError creates an [Error] with a single detail.
+func NewError(statusCode int, detail string) *Error {
+	return &Error{StatusCode: statusCode, Details: []ErrorDetail{{Detail: detail}}}
+}
+
+func (e *Error) Error() string {
+	if len(e.Details) == 0 {
+		return http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Details[0].Detail)
+}
+
+// newParseError creates a 400 [Error] for a message that could not be parsed. Schema violations are reported
+// as individual details that point into the request body.
+func newParseError(pointer string, detail string, err error) *Error {
+	var verr *validator.ValidationError
+	if !errors.As(err, &verr) {
+		return NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", detail, err))
+	}
+
+	details := make([]ErrorDetail, 0, len(verr.Details))
+	for _, d := range verr.Details {
+		details = append(details, ErrorDetail{
+			Title:  detail,
+			Detail: d.Message,
+			Source: &ErrorSource{Pointer: pointer + d.Pointer},
+			Meta:   map[string]any{"keyword": d.Keyword},
+		})
+	}
+
+	return &Error{StatusCode: http.StatusBadRequest, Details: details}
+}
+
+// newRequirementsError creates a 400 [Error] with a detail for every offering requirement the rfq failed.
+// The failures are found by unwrapping err until an error joining multiple errors is found.
+func newRequirementsError(err error) *Error {
+	errs := []error{err}
+
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		errs = joined.Unwrap()
+	}
+
+	details := make([]ErrorDetail, 0, len(errs))
+	for _, err := range errs {
+		details = append(details, ErrorDetail{
+			Title:  "rfq does not satisfy offering requirements",
+			Detail: err.Error(),
+		})
+	}
+
+	return &Error{StatusCode: http.StatusBadRequest, Details: details}
+}
+
+// writeError writes err as an [ErrorResponse]. errors that aren't an [*Error] are written as a 500.
+func writeError(w http.ResponseWriter, err error) {
+	var httpErr *Error
+	if !errors.As(err, &httpErr) {
+		httpErr = NewError(http.StatusInternalServerError, "internal server error")
+	}
+
+	details := httpErr.Details
+	if details == nil {
+		details = []ErrorDetail{{Detail: http.StatusText(httpErr.StatusCode)}}
+	}
+
+	writeJSON(w, httpErr.StatusCode, ErrorResponse{Errors: details})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
@@ -0,0 +1,630 @@
+// Package httpserver implements the PFI side of the [tbDEX HTTP API]. PFIs provide business logic via callbacks
+// while the server takes care of routing, parsing, validation, signature verification and error responses.
+//
+// [tbDEX HTTP API]: https://github.com/TBD54566975/tbdex/tree/main/specs/http-api
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/auth"
+	"github.com/TBD54566975/tbdex-go/tbdex/balance"
+	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/quote"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/store"
+)
+
+// maxBodySize is the maximum size of a request body accepted by the server.
+const maxBodySize = 1 << 20
+
+// DefaultRevisionWindow is how far in the past an RFQ's createdAt may be for the RFQ to be evaluated against the
+// offering revision that was current at that time, unless overridden with [RevisionWindow].
+const DefaultRevisionWindow = 5 * time.Minute
+
+// Server is an [http.Handler] that serves the tbDEX HTTP API on behalf of a PFI.
+type Server struct {
+	pfiDID string
+	mux    *http.ServeMux
+	clock  clock.Clock
+
+	revisionWindow time.Duration
+
+	verifyToken      TokenVerifier
+	exchanges        store.ExchangesStore
+	getOfferings     func(ctx context.Context) ([]offering.Offering, error)
+	getOfferingAt    func(ctx context.Context, id string, t time.Time) (offering.Offering, error)
+	getBalances      func(ctx context.Context, requester string) ([]balance.Balance, error)
+	getExchange      func(ctx context.Context, exchangeID string) ([]tbdex.Message, error)
+	getExchanges     func(ctx context.Context, requester string, page Page) ([]string, error)
+	onCreateExchange func(ctx context.Context, rfq rfq.RFQ, opts CreateExchangeOptions) error
+	onSubmitOrder    func(ctx context.Context, order order.Order) error
+	onSubmitCancel   func(ctx context.Context, cancel cancel.Cancel) error
+}
+
+// New creates a [Server] for the PFI identified by pfiDID. Routes whose callbacks have not been provided
+// respond with 501 Not Implemented.
+func New(pfiDID string, opts ...Option) *Server {
+	s := &Server{
+		pfiDID:         pfiDID,
+		mux:            http.NewServeMux(),
+		revisionWindow: DefaultRevisionWindow,
+		verifyToken:    auth.NewVerifier(pfiDID).Verify,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /offerings", s.handleGetOfferings)
+	s.mux.HandleFunc("GET /balances", s.handleGetBalances)
+	s.mux.HandleFunc("POST /exchanges", s.handleCreateExchange)
+	s.mux.HandleFunc("PUT /exchanges/{id}", s.handleSubmitMessage)
+	s.mux.HandleFunc("GET /exchanges/{id}", s.handleGetExchange)
+	s.mux.HandleFunc("GET /exchanges", s.handleGetExchanges)
+
+	return s
+}
+
+// ServeHTTP implements [http.Handler].
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.clock != nil {
+		r = r.WithContext(clock.NewContext(r.Context(), s.clock))
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// Page describes the requested page of a paginated list endpoint. A zero Limit means no limit.
+type Page = store.Page
+
+// CreateExchangeOptions contains additional information provided by the customer when creating an exchange.
+type CreateExchangeOptions struct {
+	// ReplyTo is the URL that the customer wants to receive subsequent messages at. Empty if not provided.
+	ReplyTo string
+}
+
+// TokenVerifier verifies a bearer token presented by a customer and returns the customer's DID.
+type TokenVerifier func(ctx context.Context, token string) (string, error)
+
+// Option implements functional options pattern for [New].
+type Option func(*Server)
+
+// VerifyToken can be passed to [New] to provide custom request token verification. Defaults to an
+// [auth.Verifier] for the PFI's DID. Passing nil disables verification, in which case endpoints that require
+// the requester's identity respond with 401 Unauthorized.
+func VerifyToken(verifier TokenVerifier) Option {
+	return func(s *Server) {
+		s.verifyToken = verifier
+	}
+}
+
+// Clock can be passed to [New] to provide the clock used for time-dependent checks such as request token and
+// quote expiry. The clock is carried by the context passed to callbacks, see [clock.FromContext]. Defaults to
+// [clock.System].
+func Clock(c clock.Clock) Option {
+	return func(s *Server) {
+		s.clock = c
+	}
+}
+
+// RevisionWindow can be passed to [New] to set how far in the past an RFQ's createdAt may be for the RFQ to be
+// evaluated against the offering revision that was current at that time. RFQs created earlier are evaluated
+// against the latest revision, as createdAt is set by the customer. Defaults to [DefaultRevisionWindow].
+func RevisionWindow(d time.Duration) Option {
+	return func(s *Server) {
+		s.revisionWindow = d
+	}
+}
+
+// OnGetOfferings can be passed to [New] to provide the offerings returned by GET /offerings. The offerings
+// are also used to evaluate incoming RFQs.
+func OnGetOfferings(fn func(ctx context.Context) ([]offering.Offering, error)) Option {
+	return func(s *Server) {
+		s.getOfferings = fn
+		s.getOfferingAt = nil
+	}
+}
+
+// OnGetBalances can be passed to [New] to provide the balances returned by GET /balances.
+func OnGetBalances(fn func(ctx context.Context, requester string) ([]balance.Balance, error)) Option {
+	return func(s *Server) {
+		s.getBalances = fn
+	}
+}
+
+// OnGetExchange can be passed to [New] to provide the messages of an exchange. A nil or empty result
+// indicates the exchange does not exist. Used by GET /exchanges/{id} and to validate submitted messages.
+func OnGetExchange(fn func(ctx context.Context, exchangeID string) ([]tbdex.Message, error)) Option {
+	return func(s *Server) {
+		s.getExchange = fn
+	}
+}
+
+// OnGetExchanges can be passed to [New] to provide the ids of the requester's exchanges returned by GET /exchanges.
+func OnGetExchanges(fn func(ctx context.Context, requester string, page Page) ([]string, error)) Option {
+	return func(s *Server) {
+		s.getExchanges = fn
+	}
+}
+
+// OnCreateExchange can be passed to [New] to handle RFQs that have passed validation.
+func OnCreateExchange(fn func(ctx context.Context, rfq rfq.RFQ, opts CreateExchangeOptions) error) Option {
+	return func(s *Server) {
+		s.onCreateExchange = fn
+	}
+}
+
+// OnSubmitOrder can be passed to [New] to handle orders that have passed validation. Orders placed against an
+// expired quote are rejected before fn is called.
+func OnSubmitOrder(fn func(ctx context.Context, order order.Order) error) Option {
+	return func(s *Server) {
+		s.onSubmitOrder = fn
+	}
+}
+
+// OnSubmitCancel can be passed to [New] to handle cancels that have passed validation.
+func OnSubmitCancel(fn func(ctx context.Context, cancel cancel.Cancel) error) Option {
+	return func(s *Server) {
+		s.onSubmitCancel = fn
+	}
+}
+
+// OfferingsStore can be passed to [New] to serve offerings from the given store. Overrides [OnGetOfferings].
+// If the store is a [store.OfferingRevisionsStore], RFQs created within the [RevisionWindow] are evaluated
+// against the revision of the offering that was current when the RFQ was created.
+func OfferingsStore(offerings store.OfferingsStore) Option {
+	return func(s *Server) {
+		s.getOfferings = func(ctx context.Context) ([]offering.Offering, error) {
+			return offerings.GetOfferings(ctx, store.Page{})
+		}
+
+		s.getOfferingAt = nil
+		if revisions, ok := offerings.(store.OfferingRevisionsStore); ok {
+			s.getOfferingAt = revisions.GetOfferingAt
+		}
+	}
+}
+
+// ExchangesStore can be passed to [New] to serve exchanges from the given store. Overrides [OnGetExchange] and
+// [OnGetExchanges]. Messages that pass validation are added to the store before their callback, if any, is
+// called, so that callbacks are only called for messages the store accepted. A message remains in the store if
+// its callback fails. Messages the store rejects with [store.ErrConflict], e.g. the second of two concurrent
+// RFQs for the same exchange, result in a 409 without calling the callback.
+func ExchangesStore(exchanges store.ExchangesStore) Option {
+	return func(s *Server) {
+		s.exchanges = exchanges
+		s.getExchange = func(ctx context.Context, exchangeID string) ([]tbdex.Message, error) {
+			messages, err := exchanges.GetExchange(ctx, exchangeID)
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, nil
+			}
+
+			return messages, err
+		}
+		s.getExchanges = func(ctx context.Context, requester string, page Page) ([]string, error) {
+			return exchanges.GetExchangeIDs(ctx, store.ExchangesFilter{From: requester}, page)
+		}
+	}
+}
+
+// BalancesStore can be passed to [New] to serve balances from the given store. Overrides [OnGetBalances].
+func BalancesStore(balances store.BalancesStore) Option {
+	return func(s *Server) {
+		s.getBalances = balances.GetBalances
+	}
+}
+
+// dataResponse is the body returned by the server when a request succeeds.
+type dataResponse struct {
+	Data any `json:"data"`
+}
+
+type createExchangeRequest struct {
+	Message json.RawMessage `json:"message"`
+	ReplyTo string          `json:"replyTo,omitempty"`
+}
+
+type submitMessageRequest struct {
+	Message json.RawMessage `json:"message"`
+}
+
+func (s *Server) handleGetOfferings(w http.ResponseWriter, r *http.Request) {
+	if s.getOfferings == nil {
+		writeError(w, errNotImplemented)
+		return
+	}
+
+	offerings, err := s.getOfferings(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if offerings == nil {
+		offerings = []offering.Offering{}
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse{Data: offerings})
+}
+
+func (s *Server) handleGetBalances(w http.ResponseWriter, r *http.Request) {
+	if s.getBalances == nil {
+		writeError(w, errNotImplemented)
+		return
+	}
+
+	requester, err := s.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	balances, err := s.getBalances(r.Context(), requester)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if balances == nil {
+		balances = []balance.Balance{}
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse{Data: balances})
+}
+
+// selectOffering returns the offering the RFQ should be evaluated against, preferring the revision that was
+// current when the RFQ was created if that's within the revision window. Nil if the offering does not exist.
+func (s *Server) selectOffering(ctx context.Context, rfqMsg rfq.RFQ) (*offering.Offering, error) {
+	if s.getOfferingAt != nil {
+		// createdAt is set by the customer, so older RFQs are evaluated against the latest revision to prevent
+		// backdating an RFQ to select a revision with better terms
+		createdAt := rfqMsg.Metadata.CreatedAt.Time()
+		cutoff := clock.FromContext(ctx).Now().Add(-s.revisionWindow)
+		if !createdAt.IsZero() && !createdAt.Before(cutoff) {
+			o, err := s.getOfferingAt(ctx, rfqMsg.Data.OfferingID, createdAt)
+			if err == nil {
+				return &o, nil
+			}
+
+			if !errors.Is(err, store.ErrNotFound) {
+				return nil, err
+			}
+		}
+	}
+
+	offerings, err := s.getOfferings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range offerings {
+		if o.Metadata.ID == rfqMsg.Data.OfferingID {
+			return &o, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Server) handleCreateExchange(w http.ResponseWriter, r *http.Request) {
+	if s.getOfferings == nil {
+		writeError(w, errNotImplemented)
+		return
+	}
+
+	var body createExchangeRequest
+	if err := decodeBody(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if body.ReplyTo != "" {
+		if _, err := url.ParseRequestURI(body.ReplyTo); err != nil {
+			writeError(w, NewError(http.StatusBadRequest, "replyTo must be a valid url"))
+			return
+		}
+	}
+
+	rfqMsg, err := rfq.ParseContext(r.Context(), body.Message)
+	if err != nil {
+		writeError(w, newParseError("/message", "failed to parse rfq", err))
+		return
+	}
+
+	if rfqMsg.Metadata.To != s.pfiDID {
+		writeError(w, NewError(http.StatusBadRequest, "rfq was not sent to this pfi"))
+		return
+	}
+
+	// offering requirements are evaluated against the private data, which must match what the customer signed
+	if err := rfqMsg.VerifyPrivateData(); err != nil {
+		writeError(w, NewError(http.StatusBadRequest, fmt.Sprintf("failed to verify rfq private data: %s", err)))
+		return
+	}
+
+	if s.getExchange != nil {
+		messages, err := s.getExchange(r.Context(), rfqMsg.Metadata.ExchangeID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if len(messages) > 0 {
+			writeError(w, NewError(http.StatusConflict, fmt.Sprintf("exchange %s already exists", rfqMsg.Metadata.ExchangeID)))
+			return
+		}
+	}
+
+	selected, err := s.selectOffering(r.Context(), rfqMsg)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if selected == nil {
+		writeError(w, NewError(http.StatusBadRequest, fmt.Sprintf("offering %s not found", rfqMsg.Data.OfferingID)))
+		return
+	}
+
+	if err := rfqMsg.VerifyOfferingRequirementsContext(r.Context(), *selected, rfq.AllErrors()); err != nil {
+		err = fmt.Errorf("offering %s: %w", selected.Metadata.ID, err)
+		writeError(w, newRequirementsError(err))
+		return
+	}
+
+	// persisting first means only the request that wins a race to create the exchange reaches the callback
+	if err := s.persist(r.Context(), tbdex.NewRawMessage(rfqMsg, body.Message)); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if s.onCreateExchange != nil {
+		err := s.onCreateExchange(r.Context(), rfqMsg, CreateExchangeOptions{ReplyTo: body.ReplyTo})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) handleSubmitMessage(w http.ResponseWriter, r *http.Request) {
+	if s.getExchange == nil {
+		writeError(w, errNotImplemented)
+		return
+	}
+
+	var body submitMessageRequest
+	if err := decodeBody(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	msg, err := tbdex.ParseRawMessageContext(r.Context(), body.Message)
+	if err != nil {
+		writeError(w, newParseError("/message", "failed to parse message", err))
+		return
+	}
+
+	kind := msg.GetKind()
+	if kind != order.Kind && kind != cancel.Kind {
+		writeError(w, NewError(http.StatusBadRequest, fmt.Sprintf("message kind must be %s or %s, got: %s", order.Kind, cancel.Kind, kind)))
+		return
+	}
+
+	exchangeID := r.PathValue("id")
+	if msg.GetMetadata().ExchangeID != exchangeID {
+		writeError(w, NewError(http.StatusBadRequest, "message exchange id does not match url"))
+		return
+	}
+
+	exchange, err := s.loadExchange(r.Context(), exchangeID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := exchange.Add(msg); err != nil {
+		writeError(w, NewError(http.StatusConflict, err.Error()))
+		return
+	}
+
+	if m, ok := msg.Unwrap().(order.Order); ok {
+		if err := exchange.Quote().VerifyOrder(m, quote.OrderClock(clock.FromContext(r.Context()))); err != nil {
+			writeError(w, NewError(http.StatusBadRequest, err.Error()))
+			return
+		}
+	}
+
+	// persisting first means only the request that wins a race to add to the exchange reaches the callback
+	if err := s.persist(r.Context(), msg); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch m := msg.Unwrap().(type) {
+	case order.Order:
+		if s.onSubmitOrder != nil {
+			err = s.onSubmitOrder(r.Context(), m)
+		}
+	case cancel.Cancel:
+		if s.onSubmitCancel != nil {
+			err = s.onSubmitCancel(r.Context(), m)
+		}
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) handleGetExchange(w http.ResponseWriter, r *http.Request) {
+	if s.getExchange == nil {
+		writeError(w, errNotImplemented)
+		return
+	}
+
+	requester, err := s.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	exchange, err := s.loadExchange(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if exchange.Customer() != requester {
+		writeError(w, NewError(http.StatusForbidden, "requester is not a participant of this exchange"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse{Data: exchange.Messages()})
+}
+
+func (s *Server) handleGetExchanges(w http.ResponseWriter, r *http.Request) {
+	if s.getExchanges == nil {
+		writeError(w, errNotImplemented)
+		return
+	}
+
+	requester, err := s.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := parsePage(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ids, err := s.getExchanges(r.Context(), requester, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if ids == nil {
+		ids = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, dataResponse{Data: ids})
+}
+
+// authenticate verifies the request's bearer token and returns the requester's DID.
+func (s *Server) authenticate(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return "", &Error{
+			StatusCode: http.StatusUnauthorized,
+			Details:    []ErrorDetail{{Detail: "authorization header with bearer token is required", Source: &ErrorSource{Header: "Authorization"}}},
+		}
+	}
+
+	if s.verifyToken == nil {
+		return "", NewError(http.StatusUnauthorized, "request token verification is not configured")
+	}
+
+	requester, err := s.verifyToken(r.Context(), token)
+	if err != nil {
+		return "", &Error{
+			StatusCode: http.StatusUnauthorized,
+			Details:    []ErrorDetail{{Detail: fmt.Sprintf("invalid request token: %s", err), Source: &ErrorSource{Header: "Authorization"}}},
+		}
+	}
+
+	return requester, nil
+}
+
+// loadExchange fetches the messages of an exchange and replays them into an [tbdex.Exchange].
+func (s *Server) loadExchange(ctx context.Context, exchangeID string) (*tbdex.Exchange, error) {
+	messages, err := s.getExchange(ctx, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) == 0 {
+		return nil, NewError(http.StatusNotFound, fmt.Sprintf("exchange %s not found", exchangeID))
+	}
+
+	exchange, err := tbdex.NewExchange(messages...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load exchange %s: %w", exchangeID, err)
+	}
+
+	return exchange, nil
+}
+
+// persist adds the message to the exchanges store if one has been provided. The message retains the JSON
+// it was received as so that stores can persist it verbatim. A [store.ErrConflict] results in a 409.
+func (s *Server) persist(ctx context.Context, msg tbdex.RawMessage) error {
+	if s.exchanges == nil {
+		return nil
+	}
+
+	if err := s.exchanges.AddMessage(ctx, msg); err != nil {
+		// another request may have added a message to the exchange since it was checked
+		if errors.Is(err, store.ErrConflict) {
+			return NewError(http.StatusConflict, err.Error())
+		}
+
+		return fmt.Errorf("failed to store %s: %w", msg.GetKind(), err)
+	}
+
+	return nil
+}
+
+var errNotImplemented = NewError(http.StatusNotImplemented, "not implemented")
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(v); err != nil {
+		return NewError(http.StatusBadRequest, fmt.Sprintf("failed to decode request body: %s", err))
+	}
+
+	return nil
+}
+
+func parsePage(query url.Values) (Page, error) {
+	var page Page
+
+	for param, dst := range map[string]*int{"page[offset]": &page.Offset, "page[limit]": &page.Limit} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return Page{}, &Error{
+				StatusCode: http.StatusBadRequest,
+				Details:    []ErrorDetail{{Detail: "must be a non-negative integer", Source: &ErrorSource{Parameter: param}}},
+			}
+		}
+
+		*dst = n
+	}
+
+	return page, nil
+}
@@ -0,0 +1,572 @@
+package httpserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/httpserver"
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/quote"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/store"
+	"github.com/alecthomas/assert/v2"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+// fixture serves the tbDEX HTTP API for a single offering using an in-memory store.
+type fixture struct {
+	pfiDID    did.BearerDID
+	walletDID did.BearerDID
+	offering  offering.Offering
+	store     *store.Memory
+	server    *httpserver.Server
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	pfiDID, err := didjwk.Create()
+	assert.NoError(t, err)
+
+	walletDID, err := didjwk.Create()
+	assert.NoError(t, err)
+
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")}),
+		offering.NewPayout("MXN", []offering.PayoutMethod{offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour)}),
+		"16.665",
+		offering.NewCancellationDetails(false),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+
+	memory := store.NewMemory()
+	assert.NoError(t, memory.PutOffering(context.Background(), o))
+
+	return &fixture{
+		pfiDID:    pfiDID,
+		walletDID: walletDID,
+		offering:  o,
+		store:     memory,
+		server: httpserver.New(
+			pfiDID.URI,
+			httpserver.VerifyToken(func(ctx context.Context, token string) (string, error) {
+				return token, nil
+			}),
+			httpserver.OfferingsStore(memory),
+			httpserver.ExchangesStore(memory),
+		),
+	}
+}
+
+// messages returns the stored messages of the exchange, or nil if it does not exist.
+func (f *fixture) messages(t *testing.T, exchangeID string) []tbdex.Message {
+	t.Helper()
+
+	messages, err := f.store.GetExchange(context.Background(), exchangeID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	assert.NoError(t, err)
+
+	return messages
+}
+
+func (f *fixture) createRFQ(t *testing.T) rfq.RFQ {
+	t.Helper()
+
+	r, err := rfq.Create(
+		f.walletDID,
+		f.pfiDID.URI,
+		f.offering.Metadata.ID,
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+	)
+	assert.NoError(t, err)
+
+	return r
+}
+
+func (f *fixture) do(t *testing.T, method, path string, body any, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		assert.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+
+	req := httptest.NewRequest(method, path, &reqBody)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	f.server.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestGetOfferings(t *testing.T) {
+	f := newFixture(t)
+
+	rec := f.do(t, http.MethodGet, "/offerings", nil, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Data []offering.Offering `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, 1, len(body.Data))
+	assert.Equal(t, f.offering.Metadata.ID, body.Data[0].Metadata.ID)
+}
+
+func TestCreateExchange(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r, "replyTo": "https://wallet.example/callback"}, "")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 1, len(f.messages(t, r.Metadata.ExchangeID)))
+
+	rec = f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestCreateExchange_Invalid(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+	r.Data.Payin.Amount = "200"
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body httpserver.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.NotZero(t, body.Errors)
+}
+
+func TestCreateExchange_AllRequirementFailures(t *testing.T) {
+	f := newFixture(t)
+
+	r, err := rfq.Create(
+		f.walletDID,
+		f.pfiDID.URI,
+		f.offering.Metadata.ID,
+		rfq.Payin("100", "DEBIT_CARD"),
+		rfq.Payout("SPEI"),
+	)
+	assert.NoError(t, err)
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// every failed requirement is reported, not just the error wrapping them
+	var body httpserver.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, 2, len(body.Errors))
+	assert.Contains(t, body.Errors[0].Detail, "payin")
+	assert.Contains(t, body.Errors[1].Detail, "payout")
+}
+
+func TestCreateExchange_TamperedPrivateData(t *testing.T) {
+	f := newFixture(t)
+
+	r, err := rfq.Create(
+		f.walletDID,
+		f.pfiDID.URI,
+		f.offering.Metadata.ID,
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT", rfq.PaymentDetails(map[string]any{"clabe": "123456789012345678"})),
+	)
+	assert.NoError(t, err)
+
+	r.PrivateData.Payout.PaymentDetails = map[string]any{"clabe": "876543210987654321"}
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body httpserver.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Contains(t, body.Errors[0].Detail, "private data")
+	assert.Equal(t, 0, len(f.messages(t, r.Metadata.ExchangeID)))
+}
+
+func TestCreateExchange_UnhashedPrivateData(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+
+	// payin details injected without a hash aren't covered by the signature
+	r.PrivateData = &rfq.PrivateData{
+		Salt:  "salt",
+		Payin: rfq.PrivatePaymentDetails{PaymentDetails: map[string]any{"accountNumber": "1234567890"}},
+	}
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body httpserver.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Contains(t, body.Errors[0].Detail, "private data")
+	assert.Equal(t, 0, len(f.messages(t, r.Metadata.ExchangeID)))
+}
+
+func TestCreateExchange_SchemaViolation(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+
+	var generic map[string]any
+	data, _ := json.Marshal(r)
+	assert.NoError(t, json.Unmarshal(data, &generic))
+	delete(generic["data"].(map[string]any), "offeringId")
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": generic}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body httpserver.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, 1, len(body.Errors))
+	assert.Equal(t, "/message/data", body.Errors[0].Source.Pointer)
+}
+
+func TestCreateExchange_InvalidCreatedAt(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+
+	var generic map[string]any
+	data, _ := json.Marshal(r)
+	assert.NoError(t, json.Unmarshal(data, &generic))
+	generic["metadata"].(map[string]any)["createdAt"] = "yesterday"
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": generic}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body httpserver.ErrorResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, 1, len(body.Errors))
+	assert.Equal(t, "/message/metadata/createdAt", body.Errors[0].Source.Pointer)
+}
+
+func TestCreateExchange_UnknownOffering(t *testing.T) {
+	f := newFixture(t)
+
+	r, err := rfq.Create(
+		f.walletDID,
+		f.pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+	)
+	assert.NoError(t, err)
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSubmitOrder(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+	exchangeID := r.Metadata.ExchangeID
+
+	q, err := quote.Create(
+		f.pfiDID,
+		f.walletDID.URI,
+		exchangeID,
+		time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, f.store.AddMessage(ctx, r))
+
+	o, err := order.Create(f.walletDID, f.pfiDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	rec := f.do(t, http.MethodPut, "/exchanges/"+exchangeID, map[string]any{"message": o}, "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	assert.NoError(t, f.store.AddMessage(ctx, q))
+
+	rec = f.do(t, http.MethodPut, "/exchanges/"+exchangeID, map[string]any{"message": o}, "")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 3, len(f.messages(t, exchangeID)))
+
+	rec = f.do(t, http.MethodPut, "/exchanges/rfq_01hwztehxhe139magy0a18mzms", map[string]any{"message": o}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSubmitOrder_ExpiredQuote(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+	exchangeID := r.Metadata.ExchangeID
+
+	q, err := quote.Create(
+		f.pfiDID,
+		f.walletDID.URI,
+		exchangeID,
+		time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+		quote.CreatedAt(time.Now().Add(-time.Hour)),
+	)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, f.store.AddMessage(ctx, r))
+	assert.NoError(t, f.store.AddMessage(ctx, q))
+
+	o, err := order.Create(f.walletDID, f.pfiDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	rec := f.do(t, http.MethodPut, "/exchanges/"+exchangeID, map[string]any{"message": o}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 2, len(f.messages(t, exchangeID)))
+}
+
+func TestGetExchange(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+	exchangeID := r.Metadata.ExchangeID
+	assert.NoError(t, f.store.AddMessage(context.Background(), r))
+
+	rec := f.do(t, http.MethodGet, "/exchanges/"+exchangeID, nil, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/exchanges/"+exchangeID, nil, f.pfiDID.URI)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/exchanges/rfq_01hwztehxhe139magy0a18mzms", nil, f.walletDID.URI)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/exchanges/"+exchangeID, nil, f.walletDID.URI)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, 1, len(body.Data))
+}
+
+func TestCallbacks(t *testing.T) {
+	f := newFixture(t)
+	exchanges := map[string][]tbdex.Message{}
+
+	f.server = httpserver.New(
+		f.pfiDID.URI,
+		httpserver.VerifyToken(func(ctx context.Context, token string) (string, error) {
+			return token, nil
+		}),
+		httpserver.OnGetOfferings(func(ctx context.Context) ([]offering.Offering, error) {
+			return []offering.Offering{f.offering}, nil
+		}),
+		httpserver.OnGetExchange(func(ctx context.Context, exchangeID string) ([]tbdex.Message, error) {
+			return exchanges[exchangeID], nil
+		}),
+		httpserver.OnCreateExchange(func(ctx context.Context, r rfq.RFQ, opts httpserver.CreateExchangeOptions) error {
+			assert.Equal(t, "https://wallet.example/callback", opts.ReplyTo)
+			exchanges[r.Metadata.ExchangeID] = []tbdex.Message{r}
+			return nil
+		}),
+	)
+
+	r := f.createRFQ(t)
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r, "replyTo": "https://wallet.example/callback"}, "")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	rec = f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/exchanges/"+r.Metadata.ExchangeID, nil, f.walletDID.URI)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestNotImplemented(t *testing.T) {
+	f := newFixture(t)
+	f.server = httpserver.New(f.pfiDID.URI)
+
+	rec := f.do(t, http.MethodGet, "/offerings", nil, "")
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/balances", nil, f.walletDID.URI)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/exchanges", nil, f.walletDID.URI)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
+
+func TestStores(t *testing.T) {
+	f := newFixture(t)
+
+	f.server = httpserver.New(
+		f.pfiDID.URI,
+		httpserver.VerifyToken(func(ctx context.Context, token string) (string, error) {
+			return token, nil
+		}),
+		httpserver.OfferingsStore(f.store),
+		httpserver.ExchangesStore(f.store),
+		httpserver.BalancesStore(f.store),
+	)
+
+	r := f.createRFQ(t)
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 1, len(f.messages(t, r.Metadata.ExchangeID)))
+
+	rec = f.do(t, http.MethodGet, "/exchanges?page[limit]=1", nil, f.walletDID.URI)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Data []string `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, []string{r.Metadata.ExchangeID}, body.Data)
+
+	rec = f.do(t, http.MethodGet, "/exchanges?page[limit]=-1", nil, f.walletDID.URI)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = f.do(t, http.MethodGet, "/balances", nil, f.walletDID.URI)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// barrierExchanges holds GetExchange until every request has called it, so that concurrent requests all pass
+// the check that the exchange doesn't exist before any of them adds to it.
+type barrierExchanges struct {
+	*store.Memory
+	arrived *sync.WaitGroup
+}
+
+func (b barrierExchanges) GetExchange(ctx context.Context, exchangeID string) ([]tbdex.Message, error) {
+	messages, err := b.Memory.GetExchange(ctx, exchangeID)
+	b.arrived.Done()
+	b.arrived.Wait()
+
+	return messages, err
+}
+
+func TestCreateExchange_Concurrent(t *testing.T) {
+	f := newFixture(t)
+
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+
+	var calls atomic.Int32
+	f.server = httpserver.New(
+		f.pfiDID.URI,
+		httpserver.OfferingsStore(f.store),
+		httpserver.ExchangesStore(barrierExchanges{Memory: f.store, arrived: &arrived}),
+		httpserver.OnCreateExchange(func(ctx context.Context, r rfq.RFQ, opts httpserver.CreateExchangeOptions) error {
+			calls.Add(1)
+			return nil
+		}),
+	)
+
+	r := f.createRFQ(t)
+
+	codes := make([]int, 2)
+
+	var done sync.WaitGroup
+	for i := range codes {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			codes[i] = f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "").Code
+		}()
+	}
+	done.Wait()
+
+	slices.Sort(codes)
+	assert.Equal(t, []int{http.StatusAccepted, http.StatusConflict}, codes)
+	assert.Equal(t, 1, len(f.messages(t, r.Metadata.ExchangeID)))
+
+	// only the request whose rfq was stored reaches the callback
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCreateExchange_OfferingRevision(t *testing.T) {
+	f := newFixture(t)
+	r := f.createRFQ(t)
+
+	// the revision raising the minimum is published after the customer created the rfq
+	revised := f.offering
+	revised.Data.Payin = offering.NewPayin(
+		"USD",
+		[]offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")},
+		offering.Min("1000"),
+	)
+	assert.NoError(t, revised.Revise(f.pfiDID, time.Now().Add(time.Hour)))
+	assert.NoError(t, f.store.PutOffering(context.Background(), revised))
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
+
+func TestCreateExchange_BackdatedRFQ(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")}),
+		offering.NewPayout("MXN", []offering.PayoutMethod{offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour)}),
+		"16.665",
+		offering.NewCancellationDetails(false),
+		offering.From(f.pfiDID),
+		offering.CreatedAt(time.Now().Add(-2*time.Hour)),
+		offering.UpdatedAt(time.Now().Add(-2*time.Hour)),
+	)
+	assert.NoError(t, err)
+
+	assert.NoError(t, f.store.PutOffering(ctx, o))
+
+	revised := o
+	revised.Data.Payin = offering.NewPayin(
+		"USD",
+		[]offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")},
+		offering.Min("1000"),
+	)
+	assert.NoError(t, revised.Revise(f.pfiDID, time.Now()))
+	assert.NoError(t, f.store.PutOffering(ctx, revised))
+
+	// the customer claims to have created the rfq before the revision raising the minimum was published
+	r, err := rfq.Create(
+		f.walletDID,
+		f.pfiDID.URI,
+		o.Metadata.ID,
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+		rfq.CreatedAt(time.Now().Add(-time.Hour)),
+	)
+	assert.NoError(t, err)
+
+	rec := f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// within the revision window the rfq is evaluated against the revision current at the time
+	f.server = httpserver.New(
+		f.pfiDID.URI,
+		httpserver.OfferingsStore(f.store),
+		httpserver.ExchangesStore(f.store),
+		httpserver.RevisionWindow(2*time.Hour),
+	)
+
+	rec = f.do(t, http.MethodPost, "/exchanges", map[string]any{"message": r}, "")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
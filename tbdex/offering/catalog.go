@@ -0,0 +1,396 @@
+package offering
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// Catalog indexes offerings, typically fetched from several PFIs, by payin and payout currency, payment
+// method kind and payment method group so that the offerings fitting a customer's request can be found
+// with [Catalog.Search]. Catalog is safe for concurrent use.
+type Catalog struct {
+	mu        sync.RWMutex
+	offerings map[string]Offering
+	index     map[indexKey]map[string]struct{}
+}
+
+// indexKey identifies a set of offerings sharing the value of an indexed field.
+type indexKey struct {
+	field string
+	value string
+}
+
+const (
+	indexPayinCurrency  = "payinCurrency"
+	indexPayoutCurrency = "payoutCurrency"
+	indexPayinKind      = "payinKind"
+	indexPayoutKind     = "payoutKind"
+	indexPayinGroup     = "payinGroup"
+	indexPayoutGroup    = "payoutGroup"
+)
+
+// Query describes the exchange a customer wants to make. Empty fields match any offering.
+type Query struct {
+	PayinCurrency  string
+	PayoutCurrency string
+
+	// PayinAmount is the amount the customer wants to pay in. When provided, offerings and methods whose
+	// limits exclude the amount do not match and results are ranked by the effective rate after fees.
+	PayinAmount string
+
+	PayinKind   string
+	PayoutKind  string
+	PayinGroup  string
+	PayoutGroup string
+
+	// PayinDetails and PayoutDetails are the payment details the customer is able to provide. When not nil,
+	// methods whose required payment details list a required property that is absent do not match.
+	PayinDetails  map[string]any
+	PayoutDetails map[string]any
+
+	// Cancellable restricts the results to offerings that support cancellation.
+	Cancellable bool
+}
+
+// Match is a combination of an offering's payin and payout method that satisfies a [Query].
+type Match struct {
+	Offering     Offering
+	PayinMethod  PayinMethod
+	PayoutMethod PayoutMethod
+
+	// EffectiveRate is the payout received per unit paid in once the method fees are taken into account, i.e.
+	// (amount * rate - payout fee) / (amount + payin fee). It is the offering's rate when the query has no
+	// payin amount.
+	EffectiveRate decimal.Decimal
+
+	// PayoutAmount is the payout received after the payout fee. Zero when the query has no payin amount.
+	PayoutAmount decimal.Decimal
+}
+
+// NewCatalog creates a [Catalog] containing the given offerings.
+func NewCatalog(offerings ...Offering) *Catalog {
+	c := &Catalog{
+		offerings: make(map[string]Offering),
+		index:     make(map[indexKey]map[string]struct{}),
+	}
+
+	c.Add(offerings...)
+
+	return c
+}
+
+// Add adds offerings to the catalog, replacing any offerings with the same id.
+func (c *Catalog) Add(offerings ...Offering) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, o := range offerings {
+		id := o.Metadata.ID
+		if existing, ok := c.offerings[id]; ok {
+			c.unindex(existing)
+		}
+
+		c.offerings[id] = o
+		for _, key := range indexKeys(o) {
+			ids, ok := c.index[key]
+			if !ok {
+				ids = make(map[string]struct{})
+				c.index[key] = ids
+			}
+			ids[id] = struct{}{}
+		}
+	}
+}
+
+// Remove removes the offerings with the given ids from the catalog.
+func (c *Catalog) Remove(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		if o, ok := c.offerings[id]; ok {
+			c.unindex(o)
+			delete(c.offerings, id)
+		}
+	}
+}
+
+// Get returns the offering with the given id.
+func (c *Catalog) Get(id string) (Offering, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	o, ok := c.offerings[id]
+	return o, ok
+}
+
+// Len returns the number of offerings in the catalog.
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.offerings)
+}
+
+// Search returns every combination of payin and payout method that satisfies the query, ranked by
+// effective rate from best to worst. An error is returned if the query's payin amount is not a valid decimal.
+// Offerings whose rate, limits or fees cannot be parsed do not match.
+// Checks include:
+//   - payin and payout currencies, method kinds and method groups
+//   - payin amount against the payin method's min and max, falling back to the offering's, see [PayinDetails.Limits]
+//   - payout amount against the payout method's min and max, falling back to the offering's, see [PayoutDetails.Limits]
+//   - presence of the properties required by each method's required payment details
+//   - cancellation support
+func (c *Catalog) Search(q Query) ([]Match, error) {
+	var amount *decimal.Decimal
+	if q.PayinAmount != "" {
+		a, err := decimal.NewFromString(q.PayinAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse query payin amount: %w", err)
+		}
+		amount = &a
+	}
+
+	c.mu.RLock()
+	candidates := c.candidates(q)
+	c.mu.RUnlock()
+
+	var matches []Match
+	for _, o := range candidates {
+		matches = append(matches, match(o, q, amount)...)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if cmp := matches[i].EffectiveRate.Cmp(matches[j].EffectiveRate); cmp != 0 {
+			return cmp > 0
+		}
+
+		return matches[i].Offering.Metadata.ID < matches[j].Offering.Metadata.ID
+	})
+
+	return matches, nil
+}
+
+// candidates returns the offerings found in the index for every field set in the query. Must be called
+// with the lock held.
+func (c *Catalog) candidates(q Query) []Offering {
+	var ids map[string]struct{}
+	filtered := false
+
+	for _, key := range []indexKey{
+		{indexPayinCurrency, q.PayinCurrency},
+		{indexPayoutCurrency, q.PayoutCurrency},
+		{indexPayinKind, q.PayinKind},
+		{indexPayoutKind, q.PayoutKind},
+		{indexPayinGroup, q.PayinGroup},
+		{indexPayoutGroup, q.PayoutGroup},
+	} {
+		if key.value == "" {
+			continue
+		}
+
+		indexed := c.index[key]
+		if !filtered {
+			ids = indexed
+			filtered = true
+			continue
+		}
+
+		intersection := make(map[string]struct{})
+		for id := range ids {
+			if _, ok := indexed[id]; ok {
+				intersection[id] = struct{}{}
+			}
+		}
+		ids = intersection
+	}
+
+	var offerings []Offering
+	if !filtered {
+		for _, o := range c.offerings {
+			offerings = append(offerings, o)
+		}
+	} else {
+		for id := range ids {
+			offerings = append(offerings, c.offerings[id])
+		}
+	}
+
+	// map iteration order is random, so the offerings are sorted to keep results deterministic
+	sort.Slice(offerings, func(i, j int) bool { return offerings[i].Metadata.ID < offerings[j].Metadata.ID })
+
+	return offerings
+}
+
+// unindex removes the offering from the index. Must be called with the lock held.
+func (c *Catalog) unindex(o Offering) {
+	for _, key := range indexKeys(o) {
+		delete(c.index[key], o.Metadata.ID)
+		if len(c.index[key]) == 0 {
+			delete(c.index, key)
+		}
+	}
+}
+
+// indexKeys returns the index keys under which the offering is found.
+func indexKeys(o Offering) []indexKey {
+	var keys []indexKey
+
+	if payin := o.Data.Payin; payin != nil {
+		keys = append(keys, indexKey{indexPayinCurrency, payin.CurrencyCode})
+		for _, m := range payin.Methods {
+			keys = append(keys, indexKey{indexPayinKind, m.Kind})
+			if m.Group != "" {
+				keys = append(keys, indexKey{indexPayinGroup, m.Group})
+			}
+		}
+	}
+
+	if payout := o.Data.Payout; payout != nil {
+		keys = append(keys, indexKey{indexPayoutCurrency, payout.CurrencyCode})
+		for _, m := range payout.Methods {
+			keys = append(keys, indexKey{indexPayoutKind, m.Kind})
+			if m.Group != "" {
+				keys = append(keys, indexKey{indexPayoutGroup, m.Group})
+			}
+		}
+	}
+
+	return keys
+}
+
+// match returns the combinations of the offering's payin and payout methods that satisfy the query.
+func match(o Offering, q Query, amount *decimal.Decimal) []Match {
+	payin, payout := o.Data.Payin, o.Data.Payout
+	if payin == nil || payout == nil {
+		return nil
+	}
+
+	if q.PayinCurrency != "" && payin.CurrencyCode != q.PayinCurrency {
+		return nil
+	}
+
+	if q.PayoutCurrency != "" && payout.CurrencyCode != q.PayoutCurrency {
+		return nil
+	}
+
+	if q.Cancellable && (o.Data.Cancellation == nil || !o.Data.Cancellation.Enabled) {
+		return nil
+	}
+
+	rate, err := decimal.NewFromString(o.Data.Rate)
+	if err != nil {
+		return nil
+	}
+
+	var payoutSubtotal decimal.Decimal
+	if amount != nil {
+		payoutSubtotal = amount.Mul(rate)
+	}
+
+	var matches []Match
+	for _, pin := range payin.Methods {
+		if !methodMatches(pin.Kind, pin.Group, pin.RequiredPaymentDetails, q.PayinKind, q.PayinGroup, q.PayinDetails) {
+			continue
+		}
+
+		payinFee, err := pin.ParseFee()
+		if err != nil {
+			continue
+		}
+
+		if amount != nil {
+			minAmount, maxAmount := payin.Limits(pin)
+			if !withinLimits(*amount, minAmount, maxAmount) {
+				continue
+			}
+		}
+
+		for _, pout := range payout.Methods {
+			if !methodMatches(pout.Kind, pout.Group, pout.RequiredPaymentDetails, q.PayoutKind, q.PayoutGroup, q.PayoutDetails) {
+				continue
+			}
+
+			payoutFee, err := pout.ParseFee()
+			if err != nil {
+				continue
+			}
+
+			m := Match{Offering: o, PayinMethod: pin, PayoutMethod: pout, EffectiveRate: rate}
+			if amount != nil {
+				minAmount, maxAmount := payout.Limits(pout)
+				if !withinLimits(payoutSubtotal, minAmount, maxAmount) {
+					continue
+				}
+
+				m.PayoutAmount = payoutSubtotal.Sub(payoutFee)
+				if !m.PayoutAmount.IsPositive() {
+					continue
+				}
+
+				total := amount.Add(payinFee)
+				if total.IsPositive() {
+					m.EffectiveRate = m.PayoutAmount.Div(total)
+				}
+			}
+
+			matches = append(matches, m)
+		}
+	}
+
+	return matches
+}
+
+// methodMatches checks a payment method's kind, group and required payment details against the query.
+func methodMatches(kind, group string, required json.RawMessage, wantKind, wantGroup string, details map[string]any) bool {
+	if wantKind != "" && kind != wantKind {
+		return false
+	}
+
+	if wantGroup != "" && group != wantGroup {
+		return false
+	}
+
+	if details == nil || len(required) == 0 {
+		return true
+	}
+
+	var schema struct {
+		Required []string `json:"required"`
+	}
+
+	if err := json.Unmarshal(required, &schema); err != nil {
+		return false
+	}
+
+	for _, property := range schema.Required {
+		if _, ok := details[property]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withinLimits checks that the amount is within the optional min and max.
+func withinLimits(amount decimal.Decimal, minAmount, maxAmount string) bool {
+	if minAmount != "" {
+		limit, err := decimal.NewFromString(minAmount)
+		if err != nil || amount.LessThan(limit) {
+			return false
+		}
+	}
+
+	if maxAmount != "" {
+		limit, err := decimal.NewFromString(maxAmount)
+		if err != nil || amount.GreaterThan(limit) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,209 @@
+package offering_test
+
+import (
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func createCatalogOffering(t *testing.T, payin *offering.PayinDetails, payout *offering.PayoutDetails, rate string, opts ...offering.CreateOption) offering.Offering {
+	t.Helper()
+
+	o, err := offering.Create(payin, payout, rate, offering.NewCancellationDetails(false), opts...)
+	assert.NoError(t, err)
+
+	return o
+}
+
+func TestCatalog_Search(t *testing.T) {
+	cheap := createCatalogOffering(t,
+		offering.NewPayin("USD", []offering.PayinMethod{
+			offering.NewPayinMethod("DEBIT_CARD", offering.MethodFee("5")),
+		}, offering.Min("10"), offering.Max("1000")),
+		offering.NewPayout("KES", []offering.PayoutMethod{
+			offering.NewPayoutMethod("MOMO_MPESA", 0),
+		}),
+		"130",
+	)
+
+	feeless := createCatalogOffering(t,
+		offering.NewPayin("USD", []offering.PayinMethod{
+			offering.NewPayinMethod("DEBIT_CARD"),
+			offering.NewPayinMethod("USD_BANK_TRANSFER", offering.MethodMin("500")),
+		}),
+		offering.NewPayout("KES", []offering.PayoutMethod{
+			offering.NewPayoutMethod("MOMO_MPESA", 0),
+		}),
+		"128",
+	)
+
+	other := createCatalogOffering(t,
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("DEBIT_CARD")}),
+		offering.NewPayout("MXN", []offering.PayoutMethod{offering.NewPayoutMethod("SPEI", 0)}),
+		"17",
+	)
+
+	catalog := offering.NewCatalog(cheap, feeless, other)
+	assert.Equal(t, 3, catalog.Len())
+
+	matches, err := catalog.Search(offering.Query{
+		PayinCurrency:  "USD",
+		PayoutCurrency: "KES",
+		PayinAmount:    "100",
+		PayinKind:      "DEBIT_CARD",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(matches))
+
+	// 100 * 128 / 100 beats 100 * 130 / 105
+	assert.Equal(t, feeless.Metadata.ID, matches[0].Offering.Metadata.ID)
+	assert.Equal(t, "128", matches[0].EffectiveRate.String())
+	assert.Equal(t, "12800", matches[0].PayoutAmount.String())
+	assert.Equal(t, cheap.Metadata.ID, matches[1].Offering.Metadata.ID)
+
+	// below the offering's min
+	matches, err = catalog.Search(offering.Query{PayinCurrency: "USD", PayoutCurrency: "KES", PayinAmount: "5"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(matches))
+	assert.Equal(t, feeless.Metadata.ID, matches[0].Offering.Metadata.ID)
+	assert.Equal(t, "DEBIT_CARD", matches[0].PayinMethod.Kind)
+
+	// method min excludes bank transfers below 500
+	matches, err = catalog.Search(offering.Query{PayinKind: "USD_BANK_TRANSFER", PayinAmount: "100"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(matches))
+
+	matches, err = catalog.Search(offering.Query{PayinKind: "USD_BANK_TRANSFER", PayinAmount: "500"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(matches))
+
+	matches, err = catalog.Search(offering.Query{PayoutCurrency: "EUR"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(matches))
+
+	matches, err = catalog.Search(offering.Query{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(matches))
+}
+
+func TestCatalog_SearchMethodLimits(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	// the bank transfer's max overrides the offering's, as it does when verifying an rfq
+	o := createCatalogOffering(t,
+		offering.NewPayin("USD", []offering.PayinMethod{
+			offering.NewPayinMethod("DEBIT_CARD"),
+			offering.NewPayinMethod("USD_BANK_TRANSFER", offering.MethodMax("5000")),
+		}, offering.Max("1000")),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"130",
+		offering.From(pfiDID),
+	)
+
+	catalog := offering.NewCatalog(o)
+
+	matches, err := catalog.Search(offering.Query{PayinAmount: "2000"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(matches))
+	assert.Equal(t, "USD_BANK_TRANSFER", matches[0].PayinMethod.Kind)
+
+	for _, kind := range []string{"DEBIT_CARD", "USD_BANK_TRANSFER"} {
+		r, err := rfq.Create(walletDID, pfiDID.URI, o.Metadata.ID, rfq.Payin("2000", kind), rfq.Payout("MOMO_MPESA"))
+		assert.NoError(t, err)
+
+		matches, err := catalog.Search(offering.Query{PayinKind: kind, PayinAmount: "2000"})
+		assert.NoError(t, err)
+		assert.Equal(t, r.VerifyOfferingRequirements(o) == nil, len(matches) > 0, kind)
+	}
+}
+
+func TestCatalog_SearchRequiredDetailsAndCancellation(t *testing.T) {
+	requiresDetails := createCatalogOffering(t,
+		offering.NewPayin("USD", []offering.PayinMethod{
+			offering.NewPayinMethod("DEBIT_CARD", offering.RequiredDetails(`{
+				"type": "object",
+				"properties": {"cardNumber": {"type": "string"}},
+				"required": ["cardNumber"]
+			}`)),
+		}),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"130",
+	)
+
+	cancellable, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("DEBIT_CARD")}),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"120",
+		offering.NewCancellationDetails(true),
+	)
+	assert.NoError(t, err)
+
+	catalog := offering.NewCatalog(requiresDetails, cancellable)
+
+	matches, err := catalog.Search(offering.Query{PayinDetails: map[string]any{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(matches))
+	assert.Equal(t, cancellable.Metadata.ID, matches[0].Offering.Metadata.ID)
+
+	matches, err = catalog.Search(offering.Query{PayinDetails: map[string]any{"cardNumber": "4111111111111111"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(matches))
+
+	matches, err = catalog.Search(offering.Query{Cancellable: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(matches))
+	assert.Equal(t, cancellable.Metadata.ID, matches[0].Offering.Metadata.ID)
+}
+
+func TestCatalog_AddRemove(t *testing.T) {
+	o := createCatalogOffering(t,
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("DEBIT_CARD")}),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"130",
+	)
+
+	catalog := offering.NewCatalog(o)
+
+	// replacing the offering re-indexes it
+	updated := o
+	updated.Data.Payout = offering.NewPayout("GHS", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MTN", 0)})
+	catalog.Add(updated)
+
+	assert.Equal(t, 1, catalog.Len())
+
+	matches, err := catalog.Search(offering.Query{PayoutCurrency: "KES"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(matches))
+
+	matches, err = catalog.Search(offering.Query{PayoutCurrency: "GHS"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(matches))
+
+	got, ok := catalog.Get(o.Metadata.ID)
+	assert.True(t, ok)
+	assert.Equal(t, "GHS", got.Data.Payout.CurrencyCode)
+
+	catalog.Remove(o.Metadata.ID)
+	assert.Equal(t, 0, catalog.Len())
+
+	matches, err = catalog.Search(offering.Query{PayoutCurrency: "GHS"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(matches))
+}
+
+func TestCatalog_SearchInvalidPayinAmount(t *testing.T) {
+	o := createCatalogOffering(t,
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("DEBIT_CARD")}),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"130",
+	)
+
+	catalog := offering.NewCatalog(o)
+
+	_, err := catalog.Search(offering.Query{PayinAmount: "one hundred"})
+	assert.Error(t, err)
+}
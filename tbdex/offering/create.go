@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/resource"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
+	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"github.com/tbd54566975/web5-go/pexv2"
 	"go.jetpack.io/typeid"
@@ -20,8 +23,6 @@ import (
 func Create(payin *PayinDetails, payout *PayoutDetails, rate string, cancellationDetails *Cancellation, opts ...CreateOption) (Offering, error) {
 	o := createOptions{
 		id:          typeid.Must(typeid.New[ID]()),
-		createdAt:   time.Now(),
-		updatedAt:   time.Now(),
 		description: fmt.Sprintf("%s for %s", payout.CurrencyCode, payin.CurrencyCode),
 		protocol:    "1.0",
 	}
@@ -30,6 +31,19 @@ func Create(payin *PayinDetails, payout *PayoutDetails, rate string, cancellatio
 		opt(&o)
 	}
 
+	now := clock.OrDefault(o.clock).Now()
+	if o.createdAt.IsZero() {
+		o.createdAt = now
+	}
+
+	if o.updatedAt.IsZero() {
+		o.updatedAt = now
+	}
+
+	if !validator.SupportsProtocol(o.protocol) {
+		return Offering{}, fmt.Errorf("unsupported protocol: %s", o.protocol)
+	}
+
 	if len(payin.Methods) == 0 {
 		return Offering{}, errors.New("at least 1 payin method is required")
 	}
@@ -42,8 +56,8 @@ func Create(payin *PayinDetails, payout *PayoutDetails, rate string, cancellatio
 		Metadata: resource.Metadata{
 			Kind:      Kind,
 			ID:        o.id.String(),
-			CreatedAt: o.createdAt.UTC().Format(time.RFC3339),
-			UpdatedAt: o.updatedAt.UTC().Format(time.RFC3339),
+			CreatedAt: timestamp.New(o.createdAt),
+			UpdatedAt: timestamp.New(o.updatedAt),
 			Protocol:  o.protocol,
 		},
 		Data: Data{
@@ -56,6 +70,12 @@ func Create(payin *PayinDetails, payout *PayoutDetails, rate string, cancellatio
 		},
 	}
 
+	if o.strict {
+		if err := offering.Lint(); err != nil {
+			return Offering{}, fmt.Errorf("inconsistent offering: %w", err)
+		}
+	}
+
 	if o.from != nil {
 		if err := offering.Sign(*o.from); err != nil {
 			return Offering{}, err
@@ -157,6 +177,8 @@ type createOptions struct {
 	protocol       string
 	requiredClaims *pexv2.PresentationDefinition
 	from           *did.BearerDID
+	strict         bool
+	clock          clock.Clock
 }
 
 // CreateOption implements functional options pattern for [Create].
@@ -169,6 +191,13 @@ func OfferingID(id ID) CreateOption {
 	}
 }
 
+// Protocol can be passed to [Create] to set the protocol version. Defaults to "1.0".
+func Protocol(version string) CreateOption {
+	return func(o *createOptions) {
+		o.protocol = version
+	}
+}
+
 // CreatedAt can be passed to [Create] to provide a custom created at time.
 func CreatedAt(t time.Time) CreateOption {
 	return func(o *createOptions) {
@@ -176,6 +205,14 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at and updated at times.
+// Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(o *createOptions) {
+		o.clock = c
+	}
+}
+
 // UpdatedAt can be passed to [Create] to provide a custom updated at time.
 func UpdatedAt(t time.Time) CreateOption {
 	return func(o *createOptions) {
@@ -197,6 +234,13 @@ func From(d did.BearerDID) CreateOption {
 	}
 }
 
+// Strict can be passed to [Create] in order to refuse offerings for which [Offering.Lint] reports problems.
+func Strict() CreateOption {
+	return func(o *createOptions) {
+		o.strict = true
+	}
+}
+
 type cancellationDetailOptions struct {
 	TermsURL string
 	Terms    string
@@ -0,0 +1,210 @@
+package offering
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/pexv2"
+
+	jsonschema "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// LintError is returned by [Offering.Lint] when the offering is not self-consistent.
+type LintError struct {
+	Issues []LintIssue
+}
+
+// LintIssue describes a single problem with an offering.
+type LintIssue struct {
+	// Pointer is a JSON pointer to the offending value within the offering e.g. /data/payin/methods/0/fee
+	Pointer string
+	// Message describes the problem
+	Message string
+}
+
+func (e *LintError) Error() string {
+	msgs := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		msgs = append(msgs, issue.Pointer+": "+issue.Message)
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Lint checks that the offering is self-consistent and returns a [*LintError] listing every problem found.
+// Specifically this includes the following checks:
+//   - rate is a positive decimal
+//   - payin and payout are present with at least 1 method each
+//   - min, max and fee at the offering and method levels are non-negative decimals
+//   - min does not exceed max at the offering and method levels
+//   - method kinds are unique within payin and payout
+//   - every method's required payment details compile as a JSON Schema
+//   - required claims are a usable presentation definition
+func (o Offering) Lint() error {
+	l := linter{}
+
+	rate := l.parse("/data/payoutUnitsPerPayinUnit", o.Data.Rate, false)
+	if rate != nil && !rate.IsPositive() {
+		l.add("/data/payoutUnitsPerPayinUnit", "rate must be positive, got %s", o.Data.Rate)
+	}
+
+	if payin := o.Data.Payin; payin == nil {
+		l.add("/data/payin", "payin is required")
+	} else {
+		l.limits("/data/payin", payin.Min, payin.Max)
+		if len(payin.Methods) == 0 {
+			l.add("/data/payin/methods", "at least 1 payin method is required")
+		}
+
+		kinds := map[string]bool{}
+		for i, m := range payin.Methods {
+			pointer := fmt.Sprintf("/data/payin/methods/%d", i)
+			l.method(pointer, m.Kind, m.Min, m.Max, m.Fee, m.RequiredPaymentDetails, kinds)
+		}
+	}
+
+	if payout := o.Data.Payout; payout == nil {
+		l.add("/data/payout", "payout is required")
+	} else {
+		l.limits("/data/payout", payout.Min, payout.Max)
+		if len(payout.Methods) == 0 {
+			l.add("/data/payout/methods", "at least 1 payout method is required")
+		}
+
+		kinds := map[string]bool{}
+		for i, m := range payout.Methods {
+			pointer := fmt.Sprintf("/data/payout/methods/%d", i)
+			l.method(pointer, m.Kind, m.Min, m.Max, m.Fee, m.RequiredPaymentDetails, kinds)
+		}
+	}
+
+	if o.Data.RequiredClaims != nil {
+		l.presentationDefinition("/data/requiredClaims", *o.Data.RequiredClaims)
+	}
+
+	if len(l.issues) == 0 {
+		return nil
+	}
+
+	return &LintError{Issues: l.issues}
+}
+
+// linter accumulates the issues found by [Offering.Lint].
+type linter struct {
+	issues []LintIssue
+}
+
+func (l *linter) add(pointer, format string, args ...any) {
+	l.issues = append(l.issues, LintIssue{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+// parse parses value, reporting an issue if it is not a decimal. An empty optional value returns nil.
+func (l *linter) parse(pointer, value string, optional bool) *decimal.Decimal {
+	if value == "" {
+		if !optional {
+			l.add(pointer, "value is required")
+		}
+		return nil
+	}
+
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		l.add(pointer, "%s is not a decimal", value)
+		return nil
+	}
+
+	return &d
+}
+
+// amount parses an optional non-negative amount.
+func (l *linter) amount(pointer, value string) *decimal.Decimal {
+	d := l.parse(pointer, value, true)
+	if d != nil && d.IsNegative() {
+		l.add(pointer, "must not be negative, got %s", value)
+		return nil
+	}
+
+	return d
+}
+
+// limits checks an optional min and max.
+func (l *linter) limits(pointer, minAmount, maxAmount string) {
+	lower := l.amount(pointer+"/min", minAmount)
+	upper := l.amount(pointer+"/max", maxAmount)
+
+	if lower != nil && upper != nil && lower.GreaterThan(*upper) {
+		l.add(pointer+"/min", "min %s is greater than max %s", minAmount, maxAmount)
+	}
+}
+
+// method checks a payin or payout method. kinds tracks the method kinds seen so far.
+func (l *linter) method(pointer, kind, minAmount, maxAmount, fee string, required json.RawMessage, kinds map[string]bool) {
+	if kinds[kind] {
+		l.add(pointer+"/kind", "duplicate method kind %s", kind)
+	}
+	kinds[kind] = true
+
+	l.limits(pointer, minAmount, maxAmount)
+	l.amount(pointer+"/fee", fee)
+
+	if len(required) == 0 {
+		return
+	}
+
+	if _, err := jsonschema.CompileString("requiredPaymentDetails", string(required)); err != nil {
+		l.add(pointer+"/requiredPaymentDetails", "invalid JSON Schema: %v", err)
+	}
+}
+
+// presentationDefinition checks that the presentation definition can be used to select credentials.
+func (l *linter) presentationDefinition(pointer string, pd pexv2.PresentationDefinition) {
+	if pd.ID == "" {
+		l.add(pointer+"/id", "presentation definition id is required")
+	}
+
+	if len(pd.InputDescriptors) == 0 {
+		l.add(pointer+"/input_descriptors", "at least 1 input descriptor is required")
+	}
+
+	ids := map[string]bool{}
+	for i, descriptor := range pd.InputDescriptors {
+		descriptorPointer := fmt.Sprintf("%s/input_descriptors/%d", pointer, i)
+
+		if descriptor.ID == "" {
+			l.add(descriptorPointer+"/id", "input descriptor id is required")
+		} else if ids[descriptor.ID] {
+			l.add(descriptorPointer+"/id", "duplicate input descriptor id %s", descriptor.ID)
+		}
+		ids[descriptor.ID] = true
+
+		for j, field := range descriptor.Constraints.Fields {
+			fieldPointer := fmt.Sprintf("%s/constraints/fields/%d", descriptorPointer, j)
+
+			if len(field.Path) == 0 {
+				l.add(fieldPointer+"/path", "at least 1 path is required")
+			}
+
+			for k, path := range field.Path {
+				if !strings.HasPrefix(path, "$") {
+					l.add(fmt.Sprintf("%s/path/%d", fieldPointer, k), "%s is not a JSONPath expression", path)
+				}
+			}
+
+			if field.Filter == nil {
+				continue
+			}
+
+			filter, err := json.Marshal(field.Filter)
+			if err != nil {
+				l.add(fieldPointer+"/filter", "failed to JSON marshal filter: %v", err)
+				continue
+			}
+
+			if _, err := jsonschema.CompileString("filter", string(filter)); err != nil {
+				l.add(fieldPointer+"/filter", "invalid filter: %v", err)
+			}
+		}
+	}
+}
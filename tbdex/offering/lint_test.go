@@ -0,0 +1,84 @@
+package offering_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/pexv2"
+)
+
+func TestLint(t *testing.T) {
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{
+			offering.NewPayinMethod("DEBIT_CARD", offering.MethodFee("1.5"), offering.RequiredDetails(`{"type": "object"}`)),
+		}, offering.Min("10"), offering.Max("1000")),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"130",
+		offering.NewCancellationDetails(false),
+		offering.Strict(),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, o.Lint())
+}
+
+func TestLint_Issues(t *testing.T) {
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{
+			offering.NewPayinMethod("DEBIT_CARD", offering.MethodFee("one dollar")),
+			offering.NewPayinMethod("DEBIT_CARD", offering.RequiredDetails(`{"type": 1}`)),
+		}, offering.Min("100"), offering.Max("10")),
+		offering.NewPayout("KES", []offering.PayoutMethod{
+			offering.NewPayoutMethod("MOMO_MPESA", 0, offering.MethodMin("-5")),
+		}),
+		"-130",
+		offering.NewCancellationDetails(false),
+		offering.RequiredClaims(pexv2.PresentationDefinition{
+			InputDescriptors: []pexv2.InputDescriptor{{
+				ID: "1",
+				Constraints: pexv2.Constraints{Fields: []pexv2.Field{{
+					Path:   []string{"type[0]"},
+					Filter: &pexv2.Filter{Type: "string", Pattern: "("},
+				}}},
+			}},
+		}),
+	)
+	assert.NoError(t, err)
+
+	err = o.Lint()
+
+	var lintErr *offering.LintError
+	assert.True(t, errors.As(err, &lintErr))
+
+	pointers := make([]string, 0, len(lintErr.Issues))
+	for _, issue := range lintErr.Issues {
+		assert.NotZero(t, issue.Message)
+		pointers = append(pointers, issue.Pointer)
+	}
+
+	assert.Equal(t, []string{
+		"/data/payoutUnitsPerPayinUnit",
+		"/data/payin/min",
+		"/data/payin/methods/0/fee",
+		"/data/payin/methods/1/kind",
+		"/data/payin/methods/1/requiredPaymentDetails",
+		"/data/payout/methods/0/min",
+		"/data/requiredClaims/id",
+		"/data/requiredClaims/input_descriptors/0/constraints/fields/0/path/0",
+		"/data/requiredClaims/input_descriptors/0/constraints/fields/0/filter",
+	}, pointers)
+}
+
+func TestCreate_Strict(t *testing.T) {
+	_, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("DEBIT_CARD")}),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"not a rate",
+		offering.NewCancellationDetails(false),
+		offering.Strict(),
+	)
+
+	var lintErr *offering.LintError
+	assert.True(t, errors.As(err, &lintErr))
+}
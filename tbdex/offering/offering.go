@@ -1,12 +1,14 @@
 package offering
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/resource"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
+	"github.com/shopspring/decimal"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"github.com/tbd54566975/web5-go/pexv2"
 	"go.jetpack.io/typeid"
@@ -79,6 +81,48 @@ type PayoutMethod struct {
 	EstimatedSettlementTime uint64          `json:"estimatedSettlementTime,omitempty"`
 }
 
+// Limits returns the min and max payin amount accepted with the given method. The method's min and max take
+// precedence over the payin's. An empty string means there is no limit.
+func (p PayinDetails) Limits(method PayinMethod) (minAmount, maxAmount string) {
+	return resolveLimits(method.Min, method.Max, p.Min, p.Max)
+}
+
+// Limits returns the min and max payout amount accepted with the given method. The method's min and max take
+// precedence over the payout's. An empty string means there is no limit.
+func (p PayoutDetails) Limits(method PayoutMethod) (minAmount, maxAmount string) {
+	return resolveLimits(method.Min, method.Max, p.Min, p.Max)
+}
+
+// ParseFee returns the fee charged for the method. An empty fee is zero.
+func (m PayinMethod) ParseFee() (decimal.Decimal, error) {
+	return parseFee(m.Fee)
+}
+
+// ParseFee returns the fee charged for the method. An empty fee is zero.
+func (m PayoutMethod) ParseFee() (decimal.Decimal, error) {
+	return parseFee(m.Fee)
+}
+
+func parseFee(fee string) (decimal.Decimal, error) {
+	if fee == "" {
+		return decimal.Zero, nil
+	}
+
+	return decimal.NewFromString(fee)
+}
+
+func resolveLimits(methodMin, methodMax, minAmount, maxAmount string) (string, string) {
+	if methodMin != "" {
+		minAmount = methodMin
+	}
+
+	if methodMax != "" {
+		maxAmount = methodMax
+	}
+
+	return minAmount, maxAmount
+}
+
 // ID is a unique identifier for an Offering.
 type ID struct {
 	typeid.TypeID[ID]
@@ -106,6 +150,21 @@ func (o Offering) Digest() ([]byte, error) {
 	return hashed, nil
 }
 
+// GetMetadata returns the metadata of the resource
+func (o Offering) GetMetadata() resource.Metadata {
+	return o.Metadata
+}
+
+// GetKind returns the kind of resource
+func (o Offering) GetKind() string {
+	return o.Metadata.Kind
+}
+
+// GetSignature returns the signature of the resource
+func (o Offering) GetSignature() string {
+	return o.Signature
+}
+
 // Sign cryptographically signs the Resource using DID's private key
 func (o *Offering) Sign(bearerDID did.BearerDID) error {
 	o.Metadata.From = bearerDID.URI
@@ -139,8 +198,13 @@ func (o *Offering) UnmarshalJSON(data []byte) error {
 }
 
 // Verify verifies the signature of the Offering.
-func (o *Offering) Verify() error {
-	decoded, err := crypto.VerifySignature(o, o.Signature)
+func (o Offering) Verify() error {
+	return o.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [Offering.Verify] but uses ctx when resolving the signer's DID.
+func (o Offering) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, o, o.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify Offering signature: %w", err)
 	}
@@ -154,11 +218,16 @@ func (o *Offering) Verify() error {
 
 // Parse validates, parses input data into an Offering, and verifies the signature.
 func (o *Offering) Parse(data []byte) error {
+	return o.ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Offering.Parse] but uses ctx when verifying the signature.
+func (o *Offering) ParseContext(ctx context.Context, data []byte) error {
 	if err := json.Unmarshal(data, &o); err != nil {
 		return fmt.Errorf("failed to unmarshal Offering: %w", err)
 	}
 
-	if err := o.Verify(); err != nil {
+	if err := o.VerifyContext(ctx); err != nil {
 		return fmt.Errorf("failed to verify Offering: %w", err)
 	}
 	return nil
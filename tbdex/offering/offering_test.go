@@ -262,3 +262,16 @@ func TestVerify_SignedWithWrongDID(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "does not match resource metadata from")
 }
+
+func TestParseFee(t *testing.T) {
+	fee, err := offering.NewPayinMethod("STORED_BALANCE", offering.MethodFee("1.5")).ParseFee()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", fee.String())
+
+	fee, err = offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour).ParseFee()
+	assert.NoError(t, err)
+	assert.True(t, fee.IsZero())
+
+	_, err = offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour, offering.MethodFee("ten")).ParseFee()
+	assert.Error(t, err)
+}
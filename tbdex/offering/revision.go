@@ -0,0 +1,299 @@
+package offering
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/did"
+)
+
+// ErrStaleRevision is returned when a revision of an offering is not updated after the revision it replaces.
+var ErrStaleRevision = errors.New("offering revision must be updated after the previous revision")
+
+// Revise turns the offering into a new revision of itself. The id and created at time are kept, the updated
+// at time is set to updatedAt and the offering is signed. updatedAt must be after the current updated at time,
+// which has a resolution of 1 second. Data should be replaced rather than modified in place, because the
+// payin and payout details are shared with copies of the previous revision.
+func (o *Offering) Revise(bearerDID did.BearerDID, updatedAt time.Time) error {
+	previous, err := o.updatedAt()
+	if err != nil {
+		return err
+	}
+
+	updatedAt = updatedAt.UTC().Truncate(time.Second)
+	if !updatedAt.After(previous) {
+		return fmt.Errorf("%w: %s is not after %s", ErrStaleRevision, timestamp.New(updatedAt), o.Metadata.UpdatedAt)
+	}
+
+	o.Metadata.UpdatedAt = timestamp.New(updatedAt)
+
+	return o.Sign(bearerDID)
+}
+
+// updatedAt returns the time the offering was last updated, falling back to its created at time.
+func (o Offering) updatedAt() (time.Time, error) {
+	updatedAt := o.Metadata.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = o.Metadata.CreatedAt
+	}
+
+	if err := updatedAt.Validate(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse offering updated at: %w", err)
+	}
+
+	return updatedAt.Time(), nil
+}
+
+// History holds the revisions of a single offering ordered by updated at time. History is not safe for
+// concurrent use.
+type History struct {
+	revisions []Offering
+	times     []time.Time
+}
+
+// Add appends a revision to the history. The revision must have the same id as the previous revisions
+// and be updated after the latest revision, otherwise [ErrStaleRevision] is returned. Adding the latest
+// revision again is a no-op.
+func (h *History) Add(o Offering) error {
+	updatedAt, err := o.updatedAt()
+	if err != nil {
+		return err
+	}
+
+	if latest, ok := h.Latest(); ok {
+		if latest.Metadata.ID != o.Metadata.ID {
+			return fmt.Errorf("offering id: %s does not match history: %s", o.Metadata.ID, latest.Metadata.ID)
+		}
+
+		if latest.Signature == o.Signature && latest.Metadata == o.Metadata {
+			return nil
+		}
+
+		if !updatedAt.After(h.times[len(h.times)-1]) {
+			return fmt.Errorf("%w: %s is not after %s", ErrStaleRevision, o.Metadata.UpdatedAt, latest.Metadata.UpdatedAt)
+		}
+	}
+
+	h.revisions = append(h.revisions, o)
+	h.times = append(h.times, updatedAt)
+
+	return nil
+}
+
+// Revisions returns every revision, oldest first.
+func (h *History) Revisions() []Offering {
+	return append([]Offering(nil), h.revisions...)
+}
+
+// Latest returns the most recent revision. False if the history is empty.
+func (h *History) Latest() (Offering, bool) {
+	if len(h.revisions) == 0 {
+		return Offering{}, false
+	}
+
+	return h.revisions[len(h.revisions)-1], true
+}
+
+// At returns the revision that was current at t i.e. the latest revision updated at or before t. An RFQ can
+// be evaluated against the revision the customer saw by passing the RFQ's created at time. False if t is
+// before the first revision.
+func (h *History) At(t time.Time) (Offering, bool) {
+	i := sort.Search(len(h.times), func(i int) bool { return h.times[i].After(t) })
+	if i == 0 {
+		return Offering{}, false
+	}
+
+	return h.revisions[i-1], true
+}
+
+// Change describes a single difference between two revisions of an offering. Added methods have an
+// empty Old value and removed methods have an empty New value.
+type Change struct {
+	// Field identifies what changed e.g. rate, payin.max or payin.methods[DEBIT_CARD].fee
+	Field string
+	Old   string
+	New   string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %q -> %q", c.Field, c.Old, c.New)
+}
+
+// Diff reports the changes between two revisions of the same offering to its rate, currencies, limits,
+// payment methods, fees, required payment details and required claims. Amounts are compared as decimals,
+// so "1.0" and "1" are considered equal.
+func Diff(old, new Offering) ([]Change, error) {
+	if old.Metadata.ID != new.Metadata.ID {
+		return nil, fmt.Errorf("offering ids do not match: %s, %s", old.Metadata.ID, new.Metadata.ID)
+	}
+
+	d := differ{}
+
+	d.amount("rate", old.Data.Rate, new.Data.Rate)
+
+	oldPayin, newPayin := old.Data.Payin, new.Data.Payin
+	if oldPayin == nil {
+		oldPayin = &PayinDetails{}
+	}
+	if newPayin == nil {
+		newPayin = &PayinDetails{}
+	}
+
+	d.string("payin.currencyCode", oldPayin.CurrencyCode, newPayin.CurrencyCode)
+	d.amount("payin.min", oldPayin.Min, newPayin.Min)
+	d.amount("payin.max", oldPayin.Max, newPayin.Max)
+	d.methods("payin", payinMethods(oldPayin.Methods), payinMethods(newPayin.Methods))
+
+	oldPayout, newPayout := old.Data.Payout, new.Data.Payout
+	if oldPayout == nil {
+		oldPayout = &PayoutDetails{}
+	}
+	if newPayout == nil {
+		newPayout = &PayoutDetails{}
+	}
+
+	d.string("payout.currencyCode", oldPayout.CurrencyCode, newPayout.CurrencyCode)
+	d.amount("payout.min", oldPayout.Min, newPayout.Min)
+	d.amount("payout.max", oldPayout.Max, newPayout.Max)
+	d.methods("payout", payoutMethods(oldPayout.Methods), payoutMethods(newPayout.Methods))
+
+	if err := d.json("requiredClaims", old.Data.RequiredClaims, new.Data.RequiredClaims); err != nil {
+		return nil, err
+	}
+
+	return d.changes, nil
+}
+
+// method holds the fields of a payin or payout method compared by [Diff].
+type method struct {
+	kind                   string
+	fee                    string
+	min                    string
+	max                    string
+	requiredPaymentDetails json.RawMessage
+}
+
+func payinMethods(methods []PayinMethod) []method {
+	converted := make([]method, 0, len(methods))
+	for _, m := range methods {
+		converted = append(converted, method{m.Kind, m.Fee, m.Min, m.Max, m.RequiredPaymentDetails})
+	}
+
+	return converted
+}
+
+func payoutMethods(methods []PayoutMethod) []method {
+	converted := make([]method, 0, len(methods))
+	for _, m := range methods {
+		converted = append(converted, method{m.Kind, m.Fee, m.Min, m.Max, m.RequiredPaymentDetails})
+	}
+
+	return converted
+}
+
+// differ accumulates the changes found by [Diff].
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) string(field, old, new string) {
+	if old != new {
+		d.changes = append(d.changes, Change{Field: field, Old: old, New: new})
+	}
+}
+
+// amount compares two optional decimals, falling back to comparing strings if either can't be parsed.
+func (d *differ) amount(field, old, new string) {
+	if old != "" && new != "" {
+		oldAmount, oldErr := decimal.NewFromString(old)
+		newAmount, newErr := decimal.NewFromString(new)
+		if oldErr == nil && newErr == nil && oldAmount.Equal(newAmount) {
+			return
+		}
+	}
+
+	d.string(field, old, new)
+}
+
+// json compares two values by their compact JSON encoding.
+func (d *differ) json(field string, old, new any) error {
+	oldJSON, err := compactJSON(old)
+	if err != nil {
+		return fmt.Errorf("failed to JSON marshal %s: %w", field, err)
+	}
+
+	newJSON, err := compactJSON(new)
+	if err != nil {
+		return fmt.Errorf("failed to JSON marshal %s: %w", field, err)
+	}
+
+	d.string(field, oldJSON, newJSON)
+
+	return nil
+}
+
+// methods compares payment methods by kind.
+func (d *differ) methods(prefix string, old, new []method) {
+	oldByKind := make(map[string]method, len(old))
+	for _, m := range old {
+		oldByKind[m.kind] = m
+	}
+
+	newKinds := make(map[string]bool, len(new))
+	for _, m := range new {
+		newKinds[m.kind] = true
+	}
+
+	for _, m := range old {
+		if !newKinds[m.kind] {
+			d.string(fmt.Sprintf("%s.methods[%s]", prefix, m.kind), m.kind, "")
+		}
+	}
+
+	for _, m := range new {
+		field := fmt.Sprintf("%s.methods[%s]", prefix, m.kind)
+
+		o, ok := oldByKind[m.kind]
+		if !ok {
+			d.string(field, "", m.kind)
+			continue
+		}
+
+		d.amount(field+".fee", o.fee, m.fee)
+		d.amount(field+".min", o.min, m.min)
+		d.amount(field+".max", o.max, m.max)
+		// required payment details are JSON Schemas, so formatting differences are ignored unless they are invalid
+		if err := d.json(field+".requiredPaymentDetails", o.requiredPaymentDetails, m.requiredPaymentDetails); err != nil {
+			d.string(field+".requiredPaymentDetails", string(o.requiredPaymentDetails), string(m.requiredPaymentDetails))
+		}
+	}
+}
+
+// compactJSON marshals v into compact JSON with object keys sorted. Null values result in an empty string.
+func compactJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return "", err
+	}
+
+	if generic == nil {
+		return "", nil
+	}
+
+	data, err = json.Marshal(generic)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,120 @@
+package offering_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func TestRevise(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("DEBIT_CARD")}),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"130",
+		offering.NewCancellationDetails(false),
+		offering.CreatedAt(createdAt),
+		offering.UpdatedAt(createdAt),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+
+	revised := o
+	revised.Data.Rate = "131"
+	assert.NoError(t, revised.Revise(pfiDID, createdAt.Add(time.Hour)))
+	assert.NoError(t, revised.Verify())
+	assert.Equal(t, o.Metadata.ID, revised.Metadata.ID)
+	assert.Equal(t, o.Metadata.CreatedAt, revised.Metadata.CreatedAt)
+	assert.Equal(t, "2024-05-01T01:00:00Z", revised.Metadata.UpdatedAt)
+
+	err = revised.Revise(pfiDID, createdAt.Add(time.Hour))
+	assert.True(t, errors.Is(err, offering.ErrStaleRevision))
+}
+
+func TestHistory(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("DEBIT_CARD")}),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"130",
+		offering.NewCancellationDetails(false),
+		offering.CreatedAt(createdAt),
+		offering.UpdatedAt(createdAt),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+
+	revised := o
+	revised.Data.Rate = "131"
+	assert.NoError(t, revised.Revise(pfiDID, createdAt.Add(time.Hour)))
+
+	history := offering.History{}
+	assert.NoError(t, history.Add(o))
+	assert.NoError(t, history.Add(revised))
+	assert.NoError(t, history.Add(revised))
+	assert.True(t, errors.Is(history.Add(o), offering.ErrStaleRevision))
+	assert.Equal(t, 2, len(history.Revisions()))
+
+	latest, ok := history.Latest()
+	assert.True(t, ok)
+	assert.Equal(t, "131", latest.Data.Rate)
+
+	at, ok := history.At(createdAt.Add(30 * time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, "130", at.Data.Rate)
+
+	at, ok = history.At(createdAt.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, "131", at.Data.Rate)
+
+	_, ok = history.At(createdAt.Add(-time.Second))
+	assert.False(t, ok)
+}
+
+func TestDiff(t *testing.T) {
+	old, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{
+			offering.NewPayinMethod("DEBIT_CARD", offering.MethodFee("1.0")),
+			offering.NewPayinMethod("USD_BANK_TRANSFER"),
+		}, offering.Max("1000")),
+		offering.NewPayout("KES", []offering.PayoutMethod{offering.NewPayoutMethod("MOMO_MPESA", 0)}),
+		"130",
+		offering.NewCancellationDetails(false),
+	)
+	assert.NoError(t, err)
+
+	updated := old
+	updated.Data.Rate = "131"
+	updated.Data.Payin = offering.NewPayin("USD", []offering.PayinMethod{
+		offering.NewPayinMethod("DEBIT_CARD", offering.MethodFee("1"), offering.MethodMax("500")),
+		offering.NewPayinMethod("APPLE_PAY"),
+	}, offering.Max("2000"))
+
+	changes, err := offering.Diff(old, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, []offering.Change{
+		{Field: "rate", Old: "130", New: "131"},
+		{Field: "payin.max", Old: "1000", New: "2000"},
+		{Field: "payin.methods[USD_BANK_TRANSFER]", Old: "USD_BANK_TRANSFER"},
+		{Field: "payin.methods[DEBIT_CARD].max", New: "500"},
+		{Field: "payin.methods[APPLE_PAY]", New: "APPLE_PAY"},
+	}, changes)
+
+	changes, err = offering.Diff(old, old)
+	assert.NoError(t, err)
+	assert.Zero(t, changes)
+
+	other, err := offering.Create(old.Data.Payin, old.Data.Payout, old.Data.Rate, old.Data.Cancellation)
+	assert.NoError(t, err)
+
+	_, err = offering.Diff(old, other)
+	assert.Error(t, err)
+}
@@ -1,15 +1,19 @@
 package order
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/message"
 	"github.com/TBD54566975/tbdex-go/tbdex/orderinstructions"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"go.jetpack.io/typeid"
@@ -42,6 +46,11 @@ func (o Order) GetKind() string {
 	return o.Metadata.Kind
 }
 
+// GetSignature returns the signature of the message
+func (o Order) GetSignature() string {
+	return o.Signature
+}
+
 // GetValidNext returns the valid message kinds that can follow an order.
 func (o Order) GetValidNext() []string {
 	return ValidNext()
@@ -74,8 +83,13 @@ func (o Order) Digest() ([]byte, error) {
 }
 
 // Verify verifies the order's signature.
-func (o *Order) Verify() error {
-	decoded, err := crypto.VerifySignature(o, o.Signature)
+func (o Order) Verify() error {
+	return o.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [Order.Verify] but uses ctx when resolving the signer's DID.
+func (o Order) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, o, o.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify order signature: %w", err)
 	}
@@ -109,13 +123,18 @@ func (o *Order) UnmarshalJSON(data []byte) error {
 
 // Parse unmarshals the provided input into an Order and then verifies the signature.
 func Parse(data []byte) (Order, error) {
+	return ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Parse] but uses ctx when verifying the signature.
+func ParseContext(ctx context.Context, data []byte) (Order, error) {
 	var o Order
 	err := json.Unmarshal(data, &o)
 	if err != nil {
 		return Order{}, fmt.Errorf("failed to unmarshal order: %w", err)
 	}
 
-	err = o.Verify()
+	err = o.VerifyContext(ctx)
 	if err != nil {
 		return o, fmt.Errorf("integrity mismatch: %w", err)
 	}
@@ -131,6 +150,26 @@ type createOptions struct {
 	id         string
 	externalID string
 	protocol   string
+	quote      ExpiringQuote
+	clock      clock.Clock
+}
+
+// ErrQuoteExpired is returned when an order is placed against a quote that has expired.
+var ErrQuoteExpired = errors.New("quote has expired")
+
+// ExpiringQuote is the quote an order is placed against. It is implemented by quote.Quote.
+type ExpiringQuote interface {
+	GetMetadata() message.Metadata
+	ExpiresAtTime() (time.Time, error)
+}
+
+// AgainstQuote can be passed to [Create] in order to refuse to create an order that fails [Order.CheckQuote]
+// against the quote, without tolerating any clock skew. A PFI applies the same checks when accepting the order
+// with quote.Quote.VerifyOrder. Fails with [ErrQuoteExpired] if the quote has expired.
+func AgainstQuote(q ExpiringQuote) CreateOption {
+	return func(o *createOptions) {
+		o.quote = q
+	}
 }
 
 // ID can be passed to [Create] to provide a custom id.
@@ -140,6 +179,13 @@ func ID(id string) CreateOption {
 	}
 }
 
+// Protocol can be passed to [Create] to set the protocol version. Defaults to "1.0".
+func Protocol(version string) CreateOption {
+	return func(r *createOptions) {
+		r.protocol = version
+	}
+}
+
 // CreatedAt can be passed to [Create] to provide a custom created at time.
 func CreatedAt(t time.Time) CreateOption {
 	return func(q *createOptions) {
@@ -147,6 +193,13 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at time. Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(q *createOptions) {
+		q.clock = c
+	}
+}
+
 // ExternalID can be passed to [Create] to provide a external id.
 func ExternalID(externalID string) CreateOption {
 	return func(q *createOptions) {
@@ -154,21 +207,74 @@ func ExternalID(externalID string) CreateOption {
 	}
 }
 
+// CheckQuote checks that the order can be placed against the quote, which should be the latest quote of the
+// exchange. Specifically this includes the following checks:
+//   - order shares the quote's exchange id
+//   - order is sent from the quote's recipient to the quote's sender
+//   - order was not created after the quote expired
+//   - quote has not expired by now
+//
+// skew tolerates clocks that are out of sync by up to skew, accepting orders up to skew after the quote expired.
+// Expiry failures wrap [ErrQuoteExpired].
+func (o Order) CheckQuote(q ExpiringQuote, now time.Time, skew time.Duration) error {
+	metadata := q.GetMetadata()
+	if o.Metadata.ExchangeID != metadata.ExchangeID {
+		return fmt.Errorf("order exchange id: %s does not match quote: %s", o.Metadata.ExchangeID, metadata.ExchangeID)
+	}
+
+	if o.Metadata.From != metadata.To {
+		return fmt.Errorf("order must be sent from %s, got: %s", metadata.To, o.Metadata.From)
+	}
+
+	if o.Metadata.To != metadata.From {
+		return fmt.Errorf("order must be sent to %s, got: %s", metadata.From, o.Metadata.To)
+	}
+
+	expiresAt, err := q.ExpiresAtTime()
+	if err != nil {
+		return err
+	}
+
+	deadline := expiresAt.Add(skew)
+
+	if err := o.Metadata.CreatedAt.Validate(); err != nil {
+		return fmt.Errorf("failed to parse order created at: %w", err)
+	}
+
+	if o.Metadata.CreatedAt.Time().After(deadline) {
+		return fmt.Errorf("%w: order created at %s, quote expired at %s", ErrQuoteExpired, o.Metadata.CreatedAt, expiresAt.UTC().Format(time.RFC3339))
+	}
+
+	if now.After(deadline) {
+		return fmt.Errorf("%w: quote expired at %s", ErrQuoteExpired, expiresAt.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 // Create creates a new order message. The following are generated by default unless custom values are provided:
 //   - created at time is set to the current time
 //   - protocol is set to "1.0"
 //   - id is autogenerated
 func Create(fromDID did.BearerDID, to, exchangeID string, opts ...CreateOption) (Order, error) {
 	options := createOptions{
-		id:        typeid.Must(typeid.WithPrefix(Kind)).String(),
-		createdAt: time.Now(),
-		protocol:  "1.0",
+		id:       typeid.Must(typeid.WithPrefix(Kind)).String(),
+		protocol: "1.0",
 	}
 
 	for _, o := range opts {
 		o(&options)
 	}
 
+	now := clock.OrDefault(options.clock).Now()
+	if options.createdAt.IsZero() {
+		options.createdAt = now
+	}
+
+	if !validator.SupportsProtocol(options.protocol) {
+		return Order{}, fmt.Errorf("unsupported protocol: %s", options.protocol)
+	}
+
 	o := Order{
 		Metadata: message.Metadata{
 			From:       fromDID.URI,
@@ -176,13 +282,19 @@ func Create(fromDID did.BearerDID, to, exchangeID string, opts ...CreateOption)
 			Kind:       Kind,
 			ID:         options.id,
 			ExchangeID: exchangeID,
-			CreatedAt:  options.createdAt.UTC().Format(time.RFC3339),
+			CreatedAt:  timestamp.New(options.createdAt),
 			ExternalID: options.externalID,
 			Protocol:   options.protocol,
 		},
 		Data: Data{},
 	}
 
+	if options.quote != nil {
+		if err := o.CheckQuote(options.quote, now, 0); err != nil {
+			return Order{}, err
+		}
+	}
+
 	signature, err := crypto.Sign(o, fromDID)
 	if err != nil {
 		return Order{}, fmt.Errorf("failed to sign order: %w", err)
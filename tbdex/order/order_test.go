@@ -2,11 +2,15 @@ package order_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
+	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/order"
 	"github.com/TBD54566975/tbdex-go/tbdex/orderinstructions"
 	"github.com/TBD54566975/tbdex-go/tbdex/orderstatus"
@@ -14,12 +18,14 @@ import (
 	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
 
 	"github.com/alecthomas/assert/v2"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/did"
 	"github.com/tbd54566975/web5-go/dids/didjwk"
 	"go.jetpack.io/typeid"
 )
 
 func TestUnmarshal(t *testing.T) {
-	vector := `{"metadata":{"kind":"order","to":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6InY4M3dmLW9ETi1idUxzam5uWFBOQ21rRlJyMDFpV3ZaTHdCNmRnNE0wbWciLCJjcnYiOiJFZDI1NTE5IiwieCI6IkU2NjJfRVM2ZW9ReE9EcFludTdmUVA4OVBrX3p2Z3NRZjdUaTZlMVhSRG8ifQ","from":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6IkZqMm80LUpmOFhCeFJmSTdZQlRuZGVGQ3Q0V3lROEdYU05lMjVqRjZOUUkiLCJjcnYiOiJFZDI1NTE5IiwieCI6IkhHdkFHTHljVjYzSV9ONEpQX2JqazRmNVRrU19qeGJHQ1A2RUtHSGlqMGsifQ","id":"order_01hwpc95zkfhd8fsfdreatfqj7","exchangeId":"rfq_01hwpc95zke09a9zdq6b78a2qv","createdAt":"2024-04-29T21:10:32.563160","protocol":"1.0"},"data":{},"signature":"eyJhbGciOiJFZERTQSIsImtpZCI6ImRpZDpqd2s6ZXlKcmRIa2lPaUpQUzFBaUxDSmhiR2NpT2lKRlpFUlRRU0lzSW10cFpDSTZJa1pxTW04MExVcG1PRmhDZUZKbVNUZFpRbFJ1WkdWR1EzUTBWM2xST0VkWVUwNWxNalZxUmpaT1VVa2lMQ0pqY25ZaU9pSkZaREkxTlRFNUlpd2llQ0k2SWtoSGRrRkhUSGxqVmpZelNWOU9ORXBRWDJKcWF6Um1OVlJyVTE5cWVHSkhRMUEyUlV0SFNHbHFNR3NpZlEjMCJ9..Uiu_nsMRcD5F2WA7gcahX61M20lEEttUpMFSCQZNuXR42RK2z_qqjYjk85EZ1M_ILywe2DtubfZZwwFuQbcAAg"}`
+	vector := `{"metadata":{"kind":"order","to":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6InY4M3dmLW9ETi1idUxzam5uWFBOQ21rRlJyMDFpV3ZaTHdCNmRnNE0wbWciLCJjcnYiOiJFZDI1NTE5IiwieCI6IkU2NjJfRVM2ZW9ReE9EcFludTdmUVA4OVBrX3p2Z3NRZjdUaTZlMVhSRG8ifQ","from":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6IkZqMm80LUpmOFhCeFJmSTdZQlRuZGVGQ3Q0V3lROEdYU05lMjVqRjZOUUkiLCJjcnYiOiJFZDI1NTE5IiwieCI6IkhHdkFHTHljVjYzSV9ONEpQX2JqazRmNVRrU19qeGJHQ1A2RUtHSGlqMGsifQ","id":"order_01hwpc95zkfhd8fsfdreatfqj7","exchangeId":"rfq_01hwpc95zke09a9zdq6b78a2qv","createdAt":"2024-04-29T21:10:32.563160Z","protocol":"1.0"},"data":{},"signature":"eyJhbGciOiJFZERTQSIsImtpZCI6ImRpZDpqd2s6ZXlKcmRIa2lPaUpQUzFBaUxDSmhiR2NpT2lKRlpFUlRRU0lzSW10cFpDSTZJa1pxTW04MExVcG1PRmhDZUZKbVNUZFpRbFJ1WkdWR1EzUTBWM2xST0VkWVUwNWxNalZxUmpaT1VVa2lMQ0pqY25ZaU9pSkZaREkxTlRFNUlpd2llQ0k2SWtoSGRrRkhUSGxqVmpZelNWOU9ORXBRWDJKcWF6Um1OVlJyVTE5cWVHSkhRMUEyUlV0SFNHbHFNR3NpZlEjMCJ9..Uiu_nsMRcD5F2WA7gcahX61M20lEEttUpMFSCQZNuXR42RK2z_qqjYjk85EZ1M_ILywe2DtubfZZwwFuQbcAAg"}`
 
 	var o order.Order
 	err := json.Unmarshal([]byte(vector), &o)
@@ -76,7 +82,7 @@ func TestParse(t *testing.T) {
 
 func TestParse_Invalid(t *testing.T) {
 	// signature is kaka
-	vector := `{"metadata":{"kind":"order","to":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6InY4M3dmLW9ETi1idUxzam5uWFBOQ21rRlJyMDFpV3ZaTHdCNmRnNE0wbWciLCJjcnYiOiJFZDI1NTE5IiwieCI6IkU2NjJfRVM2ZW9ReE9EcFludTdmUVA4OVBrX3p2Z3NRZjdUaTZlMVhSRG8ifQ","from":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6IkZqMm80LUpmOFhCeFJmSTdZQlRuZGVGQ3Q0V3lROEdYU05lMjVqRjZOUUkiLCJjcnYiOiJFZDI1NTE5IiwieCI6IkhHdkFHTHljVjYzSV9ONEpQX2JqazRmNVRrU19qeGJHQ1A2RUtHSGlqMGsifQ","id":"order_01hwpc95zkfhd8fsfdreatfqj7","exchangeId":"rfq_01hwpc95zke09a9zdq6b78a2qv","createdAt":"2024-04-29T21:10:32.563160","protocol":"1.0"},"data":{},"signature":"eyJhbGciOiJFZERTQSIsImtpZCI6ImRpZDpqd2s6ZXlKcmRIa2lPaUpQUzFBaUxDSmhiR2NpT2lKRlpFUlRRU0lzSW10cFpDSTZJa1pxTW04MExVcG1PRmhDZUZKbVNUZFpRbFJ1WkdWR1EzUTBWM2xST0VkWVUwNWxNalZxUmpaT1VVa2lMQ0pqY25ZaU9pSkZaREkxTlRFNUlpd2llQ0k2SWtoSGRrRkhUSGxqVmpZelNWOU9ORXBRWDJKcWF6Um1OVlJyVTE5cWVHSkhRMUEyUlV0SFNHbHFNR3NpZlEjMCJ9..Uiu_nsMRcD5F2WA7gcahX61M20lEEttUpMFSCQZNuXR42RK2z_qqjYjk85EZ1M_ILywe2DtubfZZwwFuQbcAAg"}`
+	vector := `{"metadata":{"kind":"order","to":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6InY4M3dmLW9ETi1idUxzam5uWFBOQ21rRlJyMDFpV3ZaTHdCNmRnNE0wbWciLCJjcnYiOiJFZDI1NTE5IiwieCI6IkU2NjJfRVM2ZW9ReE9EcFludTdmUVA4OVBrX3p2Z3NRZjdUaTZlMVhSRG8ifQ","from":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6IkZqMm80LUpmOFhCeFJmSTdZQlRuZGVGQ3Q0V3lROEdYU05lMjVqRjZOUUkiLCJjcnYiOiJFZDI1NTE5IiwieCI6IkhHdkFHTHljVjYzSV9ONEpQX2JqazRmNVRrU19qeGJHQ1A2RUtHSGlqMGsifQ","id":"order_01hwpc95zkfhd8fsfdreatfqj7","exchangeId":"rfq_01hwpc95zke09a9zdq6b78a2qv","createdAt":"2024-04-29T21:10:32.563160Z","protocol":"1.0"},"data":{},"signature":"eyJhbGciOiJFZERTQSIsImtpZCI6ImRpZDpqd2s6ZXlKcmRIa2lPaUpQUzFBaUxDSmhiR2NpT2lKRlpFUlRRU0lzSW10cFpDSTZJa1pxTW04MExVcG1PRmhDZUZKbVNUZFpRbFJ1WkdWR1EzUTBWM2xST0VkWVUwNWxNalZxUmpaT1VVa2lMQ0pqY25ZaU9pSkZaREkxTlRFNUlpd2llQ0k2SWtoSGRrRkhUSGxqVmpZelNWOU9ORXBRWDJKcWF6Um1OVlJyVTE5cWVHSkhRMUEyUlV0SFNHbHFNR3NpZlEjMCJ9..Uiu_nsMRcD5F2WA7gcahX61M20lEEttUpMFSCQZNuXR42RK2z_qqjYjk85EZ1M_ILywe2DtubfZZwwFuQbcAAg"}`
 
 	_, err := order.Parse([]byte(vector))
 	assert.Error(t, err)
@@ -84,7 +90,7 @@ func TestParse_Invalid(t *testing.T) {
 
 func TestVerify_Invalid(t *testing.T) {
 	// signature is kaka
-	vector := `{"metadata":{"kind":"order","to":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6InY4M3dmLW9ETi1idUxzam5uWFBOQ21rRlJyMDFpV3ZaTHdCNmRnNE0wbWciLCJjcnYiOiJFZDI1NTE5IiwieCI6IkU2NjJfRVM2ZW9ReE9EcFludTdmUVA4OVBrX3p2Z3NRZjdUaTZlMVhSRG8ifQ","from":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6IkZqMm80LUpmOFhCeFJmSTdZQlRuZGVGQ3Q0V3lROEdYU05lMjVqRjZOUUkiLCJjcnYiOiJFZDI1NTE5IiwieCI6IkhHdkFHTHljVjYzSV9ONEpQX2JqazRmNVRrU19qeGJHQ1A2RUtHSGlqMGsifQ","id":"order_01hwpc95zkfhd8fsfdreatfqj7","exchangeId":"rfq_01hwpc95zke09a9zdq6b78a2qv","createdAt":"2024-04-29T21:10:32.563160","protocol":"1.0"},"data":{},"signature":"eyJhbGciOiJFZERTQSIsImtpZCI6ImRpZDpqd2s6ZXlKcmRIa2lPaUpQUzFBaUxDSmhiR2NpT2lKRlpFUlRRU0lzSW10cFpDSTZJa1pxTW04MExVcG1PRmhDZUZKbVNUZFpRbFJ1WkdWR1EzUTBWM2xST0VkWVUwNWxNalZxUmpaT1VVa2lMQ0pqY25ZaU9pSkZaREkxTlRFNUlpd2llQ0k2SWtoSGRrRkhUSGxqVmpZelNWOU9ORXBRWDJKcWF6Um1OVlJyVTE5cWVHSkhRMUEyUlV0SFNHbHFNR3NpZlEjMCJ9..Uiu_nsMRcD5F2WA7gcahX61M20lEEttUpMFSCQZNuXR42RK2z_qqjYjk85EZ1M_ILywe2DtubfZZwwFuQbcAAg"}`
+	vector := `{"metadata":{"kind":"order","to":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6InY4M3dmLW9ETi1idUxzam5uWFBOQ21rRlJyMDFpV3ZaTHdCNmRnNE0wbWciLCJjcnYiOiJFZDI1NTE5IiwieCI6IkU2NjJfRVM2ZW9ReE9EcFludTdmUVA4OVBrX3p2Z3NRZjdUaTZlMVhSRG8ifQ","from":"did:jwk:eyJrdHkiOiJPS1AiLCJhbGciOiJFZERTQSIsImtpZCI6IkZqMm80LUpmOFhCeFJmSTdZQlRuZGVGQ3Q0V3lROEdYU05lMjVqRjZOUUkiLCJjcnYiOiJFZDI1NTE5IiwieCI6IkhHdkFHTHljVjYzSV9ONEpQX2JqazRmNVRrU19qeGJHQ1A2RUtHSGlqMGsifQ","id":"order_01hwpc95zkfhd8fsfdreatfqj7","exchangeId":"rfq_01hwpc95zke09a9zdq6b78a2qv","createdAt":"2024-04-29T21:10:32.563160Z","protocol":"1.0"},"data":{},"signature":"eyJhbGciOiJFZERTQSIsImtpZCI6ImRpZDpqd2s6ZXlKcmRIa2lPaUpQUzFBaUxDSmhiR2NpT2lKRlpFUlRRU0lzSW10cFpDSTZJa1pxTW04MExVcG1PRmhDZUZKbVNUZFpRbFJ1WkdWR1EzUTBWM2xST0VkWVUwNWxNalZxUmpaT1VVa2lMQ0pqY25ZaU9pSkZaREkxTlRFNUlpd2llQ0k2SWtoSGRrRkhUSGxqVmpZelNWOU9ORXBRWDJKcWF6Um1OVlJyVTE5cWVHSkhRMUEyUlV0SFNHbHFNR3NpZlEjMCJ9..Uiu_nsMRcD5F2WA7gcahX61M20lEEttUpMFSCQZNuXR42RK2z_qqjYjk85EZ1M_ILywe2DtubfZZwwFuQbcAAg"}`
 
 	var o order.Order
 	err := json.Unmarshal([]byte(vector), &o)
@@ -124,6 +130,124 @@ func TestCreate(t *testing.T) {
 	assert.Equal(t, exchangeID.String(), o.Metadata.ExchangeID)
 }
 
+func TestCreate_Clock(t *testing.T) {
+	alice, _ := didjwk.Create()
+	pfi, _ := didjwk.Create()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	exchangeID := typeid.Must(typeid.WithPrefix(rfq.Kind)).String()
+
+	o, err := order.Create(alice, pfi.URI, exchangeID, order.Clock(clock.Fixed(now)))
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-05-01T12:00:00Z", o.Metadata.CreatedAt)
+
+	// CreatedAt takes precedence over the clock
+	o, err = order.Create(alice, pfi.URI, exchangeID, order.Clock(clock.Fixed(now)), order.CreatedAt(now.Add(time.Hour)))
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-05-01T13:00:00Z", o.Metadata.CreatedAt)
+}
+
+func TestCreate_AgainstQuote(t *testing.T) {
+	alice, _ := didjwk.Create()
+	pfi, _ := didjwk.Create()
+
+	exchangeID := typeid.Must(typeid.WithPrefix(rfq.Kind)).String()
+	expiresAt := time.Now().Add(time.Hour)
+
+	q, err := quote.Create(
+		pfi,
+		alice.URI,
+		exchangeID,
+		expiresAt.UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+
+	_, err = order.Create(alice, pfi.URI, exchangeID, order.AgainstQuote(q))
+	assert.NoError(t, err)
+
+	_, err = order.Create(alice, pfi.URI, exchangeID, order.AgainstQuote(q), order.CreatedAt(expiresAt.Add(time.Minute)))
+	assert.True(t, errors.Is(err, order.ErrQuoteExpired))
+
+	// backdating the order doesn't get around an expired quote
+	clk := clock.Fixed(expiresAt.Add(time.Minute))
+	_, err = order.Create(alice, pfi.URI, exchangeID, order.AgainstQuote(q), order.Clock(clk), order.CreatedAt(time.Now()))
+	assert.True(t, errors.Is(err, order.ErrQuoteExpired))
+
+	otherExchangeID := typeid.Must(typeid.WithPrefix(rfq.Kind)).String()
+	_, err = order.Create(alice, pfi.URI, otherExchangeID, order.AgainstQuote(q))
+	assert.Error(t, err)
+}
+
+func TestCreate_AgainstQuoteParticipants(t *testing.T) {
+	alice, _ := didjwk.Create()
+	bob, _ := didjwk.Create()
+	pfi, _ := didjwk.Create()
+
+	exchangeID := typeid.Must(typeid.WithPrefix(rfq.Kind)).String()
+
+	q, err := quote.Create(
+		pfi,
+		alice.URI,
+		exchangeID,
+		time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+
+	// the order must be one the PFI accepts with quote.VerifyOrder
+	for _, tt := range []struct {
+		from did.BearerDID
+		to   string
+		ok   bool
+	}{
+		{from: alice, to: pfi.URI, ok: true},
+		{from: bob, to: pfi.URI},
+		{from: alice, to: bob.URI},
+	} {
+		o, err := order.Create(tt.from, tt.to, exchangeID, order.AgainstQuote(q))
+		assert.Equal(t, tt.ok, err == nil, err)
+
+		if tt.ok {
+			assert.NoError(t, q.VerifyOrder(o))
+		}
+	}
+}
+
+func TestCheckQuote_Skew(t *testing.T) {
+	alice, _ := didjwk.Create()
+	pfi, _ := didjwk.Create()
+
+	exchangeID := typeid.Must(typeid.WithPrefix(rfq.Kind)).String()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	q, err := quote.Create(
+		pfi,
+		alice.URI,
+		exchangeID,
+		expiresAt.UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+
+	o, err := order.Create(alice, pfi.URI, exchangeID, order.CreatedAt(expiresAt.Add(10*time.Second)))
+	assert.NoError(t, err)
+
+	err = o.CheckQuote(q, expiresAt, 0)
+	assert.True(t, errors.Is(err, order.ErrQuoteExpired))
+
+	assert.NoError(t, o.CheckQuote(q, expiresAt.Add(20*time.Second), 30*time.Second))
+
+	err = o.CheckQuote(q, expiresAt.Add(time.Minute), 30*time.Second)
+	assert.True(t, errors.Is(err, order.ErrQuoteExpired))
+}
+
 func TestSign(t *testing.T) {
 	alice, err := didjwk.Create()
 	assert.NoError(t, err)
@@ -138,6 +262,28 @@ func TestSign(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUnmarshal_SubSecondCreatedAt(t *testing.T) {
+	alice, _ := didjwk.Create()
+	pfi, _ := didjwk.Create()
+
+	exchangeID := typeid.Must(typeid.WithPrefix(rfq.Kind)).String()
+	o, err := order.Create(alice, pfi.URI, exchangeID)
+	assert.NoError(t, err)
+
+	o.Metadata.CreatedAt = "2024-04-29T21:10:32.563160Z"
+	o.Signature, err = crypto.Sign(o, alice)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(o)
+	assert.NoError(t, err)
+
+	var parsed order.Order
+	assert.NoError(t, json.Unmarshal(data, &parsed))
+	assert.Equal(t, o.Metadata.CreatedAt, parsed.Metadata.CreatedAt)
+	assert.Equal(t, 563160000, parsed.Metadata.CreatedAt.Time().Nanosecond())
+	assert.NoError(t, parsed.Verify())
+}
+
 func TestIsValidNext(t *testing.T) {
 
 	alice, err := didjwk.Create()
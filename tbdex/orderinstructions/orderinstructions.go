@@ -1,15 +1,18 @@
 package orderinstructions
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/message"
 	"github.com/TBD54566975/tbdex-go/tbdex/orderstatus"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"go.jetpack.io/typeid"
@@ -42,6 +45,11 @@ func (o OrderInstructions) GetKind() string {
 	return o.Metadata.Kind
 }
 
+// GetSignature returns the signature of the message
+func (o OrderInstructions) GetSignature() string {
+	return o.Signature
+}
+
 // GetValidNext returns the valid message kinds that can follow an orderinstructions.
 func (o OrderInstructions) GetValidNext() []string {
 	return ValidNext()
@@ -82,8 +90,13 @@ func (o OrderInstructions) Digest() ([]byte, error) {
 }
 
 // Verify verifies the orderinstructions's signature.
-func (o *OrderInstructions) Verify() error {
-	decoded, err := crypto.VerifySignature(o, o.Signature)
+func (o OrderInstructions) Verify() error {
+	return o.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [OrderInstructions.Verify] but uses ctx when resolving the signer's DID.
+func (o OrderInstructions) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, o, o.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify orderinstructions signature: %w", err)
 	}
@@ -115,13 +128,18 @@ func (o *OrderInstructions) UnmarshalJSON(data []byte) error {
 
 // Parse unmarshals the provided input into an OrderInstructions and then verifies the signature.
 func Parse(data []byte) (OrderInstructions, error) {
+	return ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Parse] but uses ctx when verifying the signature.
+func ParseContext(ctx context.Context, data []byte) (OrderInstructions, error) {
 	var o OrderInstructions
 	err := json.Unmarshal(data, &o)
 	if err != nil {
 		return OrderInstructions{}, fmt.Errorf("failed to unmarshal orderinstructions: %w", err)
 	}
 
-	err = o.Verify()
+	err = o.VerifyContext(ctx)
 	if err != nil {
 		return o, fmt.Errorf("integrity mismatch: %w", err)
 	}
@@ -139,6 +157,7 @@ type createOptions struct {
 	protocol          string
 	payinInstruction  *PaymentInstruction
 	payoutInstruction *PaymentInstruction
+	clock             clock.Clock
 }
 
 // ID can be passed to [Create] to provide a custom id.
@@ -148,6 +167,13 @@ func ID(id string) CreateOption {
 	}
 }
 
+// Protocol can be passed to [Create] to set the protocol version. Defaults to "1.0".
+func Protocol(version string) CreateOption {
+	return func(r *createOptions) {
+		r.protocol = version
+	}
+}
+
 // CreatedAt can be passed to [Create] to provide a custom created at time.
 func CreatedAt(t time.Time) CreateOption {
 	return func(q *createOptions) {
@@ -155,6 +181,13 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at time. Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(q *createOptions) {
+		q.clock = c
+	}
+}
+
 // ExternalID can be passed to [Create] to provide a external id.
 func ExternalID(externalID string) CreateOption {
 	return func(q *createOptions) {
@@ -168,15 +201,23 @@ func ExternalID(externalID string) CreateOption {
 //   - id is autogenerated
 func Create(fromDID did.BearerDID, to, exchangeID string, opts ...CreateOption) (OrderInstructions, error) {
 	options := createOptions{
-		id:        typeid.Must(typeid.WithPrefix(Kind)).String(),
-		createdAt: time.Now(),
-		protocol:  "1.0",
+		id:       typeid.Must(typeid.WithPrefix(Kind)).String(),
+		protocol: "1.0",
 	}
 
 	for _, o := range opts {
 		o(&options)
 	}
 
+	now := clock.OrDefault(options.clock).Now()
+	if options.createdAt.IsZero() {
+		options.createdAt = now
+	}
+
+	if !validator.SupportsProtocol(options.protocol) {
+		return OrderInstructions{}, fmt.Errorf("unsupported protocol: %s", options.protocol)
+	}
+
 	o := OrderInstructions{
 		Metadata: message.Metadata{
 			From:       fromDID.URI,
@@ -184,7 +225,7 @@ func Create(fromDID did.BearerDID, to, exchangeID string, opts ...CreateOption)
 			Kind:       Kind,
 			ID:         options.id,
 			ExchangeID: exchangeID,
-			CreatedAt:  options.createdAt.UTC().Format(time.RFC3339),
+			CreatedAt:  timestamp.New(options.createdAt),
 			ExternalID: options.externalID,
 			Protocol:   options.protocol,
 		},
@@ -1,14 +1,17 @@
 package orderstatus
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/message"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"go.jetpack.io/typeid"
@@ -39,6 +42,11 @@ func (os OrderStatus) GetKind() string {
 	return os.Metadata.Kind
 }
 
+// GetSignature returns the signature of the message
+func (os OrderStatus) GetSignature() string {
+	return os.Signature
+}
+
 // GetValidNext returns the valid next message kinds that can follow an orderstatus
 func (os OrderStatus) GetValidNext() []string {
 	return ValidNext()
@@ -91,8 +99,13 @@ func (os OrderStatus) Digest() ([]byte, error) {
 }
 
 // Verify verifies the signature of the OrderStatus.
-func (os *OrderStatus) Verify() error {
-	decoded, err := crypto.VerifySignature(os, os.Signature)
+func (os OrderStatus) Verify() error {
+	return os.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [OrderStatus.Verify] but uses ctx when resolving the signer's DID.
+func (os OrderStatus) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, os, os.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify OrderStatus signature: %w", err)
 	}
@@ -124,13 +137,18 @@ func (os *OrderStatus) UnmarshalJSON(data []byte) error {
 
 // Parse validates and unmarshals the input data into an OrderStatus.
 func Parse(data []byte) (OrderStatus, error) {
+	return ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Parse] but uses ctx when verifying the signature.
+func ParseContext(ctx context.Context, data []byte) (OrderStatus, error) {
 	os := OrderStatus{}
 	if err := json.Unmarshal(data, &os); err != nil {
 		return OrderStatus{}, fmt.Errorf("failed to unmarshal order status: %w", err)
 
 	}
 
-	if err := os.Verify(); err != nil {
+	if err := os.VerifyContext(ctx); err != nil {
 		return OrderStatus{}, fmt.Errorf("failed to verify order status: %w", err)
 	}
 
@@ -140,15 +158,23 @@ func Parse(data []byte) (OrderStatus, error) {
 // Create creates a new OrderStatus message.
 func Create(fromDID did.BearerDID, to, exchangeID string, status Status, opts ...CreateOption) (OrderStatus, error) {
 	o := createOptions{
-		id:        typeid.Must(typeid.WithPrefix(Kind)).String(),
-		createdAt: time.Now(),
-		protocol:  "1.0",
+		id:       typeid.Must(typeid.WithPrefix(Kind)).String(),
+		protocol: "1.0",
 	}
 
 	for _, opt := range opts {
 		opt(&o)
 	}
 
+	now := clock.OrDefault(o.clock).Now()
+	if o.createdAt.IsZero() {
+		o.createdAt = now
+	}
+
+	if !validator.SupportsProtocol(o.protocol) {
+		return OrderStatus{}, fmt.Errorf("unsupported protocol: %s", o.protocol)
+	}
+
 	os := OrderStatus{
 		Metadata: message.Metadata{
 			From:       fromDID.URI,
@@ -156,7 +182,7 @@ func Create(fromDID did.BearerDID, to, exchangeID string, status Status, opts ..
 			Kind:       Kind,
 			ID:         o.id,
 			ExchangeID: exchangeID,
-			CreatedAt:  o.createdAt.UTC().Format(time.RFC3339),
+			CreatedAt:  timestamp.New(o.createdAt),
 			ExternalID: o.externalID,
 			Protocol:   o.protocol,
 		},
@@ -179,6 +205,7 @@ type createOptions struct {
 	protocol   string
 	externalID string
 	detail     string
+	clock      clock.Clock
 }
 
 // CreateOption defines a type for functions that can modify the createOptions struct.
@@ -191,6 +218,13 @@ func ID(id string) CreateOption {
 	}
 }
 
+// Protocol can be passed to [Create] to set the protocol version. Defaults to "1.0".
+func Protocol(version string) CreateOption {
+	return func(r *createOptions) {
+		r.protocol = version
+	}
+}
+
 // CreatedAt can be passed to [Create] to provide a custom created at time.
 func CreatedAt(t time.Time) CreateOption {
 	return func(q *createOptions) {
@@ -198,6 +232,13 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at time. Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(q *createOptions) {
+		q.clock = c
+	}
+}
+
 // ExternalID can be passed to [Create] to provide a custom external id.
 func ExternalID(externalID string) CreateOption {
 	return func(q *createOptions) {
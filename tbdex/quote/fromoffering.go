@@ -0,0 +1,189 @@
+package quote
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/did"
+)
+
+// DefaultPrecision is the number of decimal places computed amounts are rounded to for currencies
+// whose precision has not been provided with [CurrencyPrecision].
+const DefaultPrecision int32 = 2
+
+// RoundingMode determines how computed amounts are rounded to a currency's precision.
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // RoundHalfUp rounds to the nearest value, away from zero when halfway
+	RoundHalfEven                     // RoundHalfEven rounds to the nearest value, to the even neighbour when halfway
+	RoundDown                         // RoundDown rounds towards zero
+	RoundUp                           // RoundUp rounds away from zero
+)
+
+// round rounds d to the given number of decimal places.
+func (m RoundingMode) round(d decimal.Decimal, places int32) decimal.Decimal {
+	switch m {
+	case RoundHalfEven:
+		return d.RoundBank(places)
+	case RoundDown:
+		return d.Truncate(places)
+	case RoundUp:
+		truncated := d.Truncate(places)
+		if truncated.Equal(d) {
+			return truncated
+		}
+
+		step := decimal.New(int64(d.Sign()), -places)
+		return truncated.Add(step)
+	default:
+		return d.Round(places)
+	}
+}
+
+type fromOfferingOptions struct {
+	rounding      RoundingMode
+	precisions    map[string]int32
+	createOptions []CreateOption
+}
+
+// FromOfferingOption implements functional options pattern for [FromOffering].
+type FromOfferingOption func(*fromOfferingOptions)
+
+// Rounding can be passed to [FromOffering] to provide a custom rounding mode. Defaults to [RoundHalfUp].
+func Rounding(mode RoundingMode) FromOfferingOption {
+	return func(o *fromOfferingOptions) {
+		o.rounding = mode
+	}
+}
+
+// CurrencyPrecision can be passed to [FromOffering] to provide the number of decimal places used for the
+// given currency. Currencies without a precision use [DefaultPrecision].
+func CurrencyPrecision(currencyCode string, places int32) FromOfferingOption {
+	return func(o *fromOfferingOptions) {
+		o.precisions[currencyCode] = places
+	}
+}
+
+// WithCreateOptions can be passed to [FromOffering] to provide options for the underlying call to [Create].
+func WithCreateOptions(opts ...CreateOption) FromOfferingOption {
+	return func(o *fromOfferingOptions) {
+		o.createOptions = append(o.createOptions, opts...)
+	}
+}
+
+// FromOffering creates a Quote in response to the rfq using the offering's rate along with the fees of the
+// payin and payout methods selected in the rfq. The rfq's payin amount is used as the payin subtotal as is and
+// must be representable at the payin currency's precision. Every computed amount and fee is rounded to its
+// currency's precision.
+//
+// Per the [QuoteDetails] definition, every total is the subtotal plus the fee. The payout fee is taken from the
+// converted payin amount, so the payout subtotal is the payin subtotal converted using the offering's rate minus
+// the payout fee, and the payout total is the converted amount.
+//
+// [QuoteDetails]: https://github.com/TBD54566975/tbdex/tree/main/specs/protocol#quotedetails
+func FromOffering(pfiDID did.BearerDID, r rfq.RFQ, o offering.Offering, expiresAt time.Time, opts ...FromOfferingOption) (Quote, error) {
+	options := fromOfferingOptions{precisions: make(map[string]int32)}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if r.Data.OfferingID != o.Metadata.ID {
+		return Quote{}, fmt.Errorf("rfq offering id: %s does not match offering: %s", r.Data.OfferingID, o.Metadata.ID)
+	}
+
+	if o.Data.Payin == nil || o.Data.Payout == nil {
+		return Quote{}, errors.New("offering is missing payin or payout details")
+	}
+
+	rate, err := decimal.NewFromString(o.Data.Rate)
+	if err != nil {
+		return Quote{}, fmt.Errorf("failed to parse offering rate: %w", err)
+	}
+
+	payinSubtotal, err := decimal.NewFromString(r.Data.Payin.Amount)
+	if err != nil {
+		return Quote{}, fmt.Errorf("failed to parse rfq payin amount: %w", err)
+	}
+
+	var payinMethod *offering.PayinMethod
+	for _, method := range o.Data.Payin.Methods {
+		if method.Kind == r.Data.Payin.Kind {
+			payinMethod = &method
+			break
+		}
+	}
+
+	if payinMethod == nil {
+		return Quote{}, fmt.Errorf("offering does not support payin method: %s", r.Data.Payin.Kind)
+	}
+
+	var payoutMethod *offering.PayoutMethod
+	for _, method := range o.Data.Payout.Methods {
+		if method.Kind == r.Data.Payout.Kind {
+			payoutMethod = &method
+			break
+		}
+	}
+
+	if payoutMethod == nil {
+		return Quote{}, fmt.Errorf("offering does not support payout method: %s", r.Data.Payout.Kind)
+	}
+
+	payinFeeAmount, err := payinMethod.ParseFee()
+	if err != nil {
+		return Quote{}, fmt.Errorf("failed to parse payin method fee: %w", err)
+	}
+
+	payoutFeeAmount, err := payoutMethod.ParseFee()
+	if err != nil {
+		return Quote{}, fmt.Errorf("failed to parse payout method fee: %w", err)
+	}
+
+	payinCurrency := o.Data.Payin.CurrencyCode
+	if places := options.precision(payinCurrency); !payinSubtotal.Equal(payinSubtotal.Truncate(places)) {
+		return Quote{}, fmt.Errorf("rfq payin amount: %s has more than %d decimal places for %s", r.Data.Payin.Amount, places, payinCurrency)
+	}
+
+	payinFeeAmount = options.round(payinFeeAmount, payinCurrency)
+
+	payoutCurrency := o.Data.Payout.CurrencyCode
+	converted := options.round(payinSubtotal.Mul(rate), payoutCurrency)
+	payoutFeeAmount = options.round(payoutFeeAmount, payoutCurrency)
+
+	if payoutFeeAmount.GreaterThan(converted) {
+		return Quote{}, fmt.Errorf("payout fee: %s exceeds converted payin amount: %s", payoutFeeAmount, converted)
+	}
+
+	// the payout fee is taken from the converted amount, so that the total is still subtotal plus fee
+	payout := NewQuoteDetails(payoutCurrency, converted.Sub(payoutFeeAmount), DetailsFee(payoutFeeAmount))
+
+	return Create(
+		pfiDID,
+		r.Metadata.From,
+		r.Metadata.ExchangeID,
+		expiresAt.UTC().Format(time.RFC3339),
+		o.Data.Rate,
+		NewQuoteDetails(payinCurrency, payinSubtotal, DetailsFee(payinFeeAmount)),
+		payout,
+		options.createOptions...,
+	)
+}
+
+// round rounds d to the currency's precision using the rounding mode.
+func (o fromOfferingOptions) round(d decimal.Decimal, currencyCode string) decimal.Decimal {
+	return o.rounding.round(d, o.precision(currencyCode))
+}
+
+// precision returns the number of decimal places used for the currency.
+func (o fromOfferingOptions) precision(currencyCode string) int32 {
+	if places, ok := o.precisions[currencyCode]; ok {
+		return places
+	}
+
+	return DefaultPrecision
+}
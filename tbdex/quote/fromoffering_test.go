@@ -0,0 +1,184 @@
+package quote_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/quote"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
+	"github.com/alecthomas/assert/v2"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func createOfferingAndRFQ(t *testing.T, pfiDID, walletDID did.BearerDID, amount string) (offering.Offering, rfq.RFQ) {
+	t.Helper()
+
+	o, err := offering.Create(
+		offering.NewPayin(
+			"USD",
+			[]offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE", offering.MethodFee("1.5"))},
+		),
+		offering.NewPayout(
+			"MXN",
+			[]offering.PayoutMethod{offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour, offering.MethodFee("10"))},
+		),
+		"16.665",
+		offering.NewCancellationDetails(false),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		o.Metadata.ID,
+		rfq.Payin(amount, "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+	)
+	assert.NoError(t, err)
+
+	return o, r
+}
+
+func TestFromOffering(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, r := createOfferingAndRFQ(t, pfiDID, walletDID, "100.01")
+
+	expiresAt := time.Now().Add(time.Hour)
+	q, err := quote.FromOffering(pfiDID, r, o, expiresAt)
+	assert.NoError(t, err)
+
+	assert.Equal(t, pfiDID.URI, q.Metadata.From)
+	assert.Equal(t, walletDID.URI, q.Metadata.To)
+	assert.Equal(t, r.Metadata.ExchangeID, q.Metadata.ExchangeID)
+	assert.Equal(t, timestamp.New(expiresAt), q.Data.ExpiresAt)
+	assert.Equal(t, "16.665", q.Data.Rate)
+
+	assert.Equal(t, quote.QuoteDetails{CurrencyCode: "USD", Subtotal: "100.01", Fee: "1.5", Total: "101.51"}, q.Data.Payin)
+	// 100.01 * 16.665 = 1666.66665
+	assert.Equal(t, quote.QuoteDetails{CurrencyCode: "MXN", Subtotal: "1656.67", Fee: "10", Total: "1666.67"}, q.Data.Payout)
+
+	assert.NoError(t, q.Verify())
+}
+
+func TestFromOffering_Rounding(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, r := createOfferingAndRFQ(t, pfiDID, walletDID, "100.01")
+
+	tests := []struct {
+		name  string
+		opts  []quote.FromOfferingOption
+		total string
+	}{
+		{name: "half up", total: "1666.67"},
+		{name: "half even", opts: []quote.FromOfferingOption{quote.Rounding(quote.RoundHalfEven), quote.CurrencyPrecision("MXN", 4)}, total: "1666.6666"},
+		{name: "down", opts: []quote.FromOfferingOption{quote.Rounding(quote.RoundDown)}, total: "1666.66"},
+		{name: "up", opts: []quote.FromOfferingOption{quote.Rounding(quote.RoundUp), quote.CurrencyPrecision("MXN", 4)}, total: "1666.6667"},
+		{name: "precision", opts: []quote.FromOfferingOption{quote.CurrencyPrecision("MXN", 0)}, total: "1667"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := quote.FromOffering(pfiDID, r, o, time.Now().Add(time.Hour), tt.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.total, q.Data.Payout.Total)
+		})
+	}
+}
+
+func TestFromOffering_RoundsEveryAmount(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, r := createOfferingAndRFQ(t, pfiDID, walletDID, "100.02")
+	o.Data.Payin.Methods[0].Fee = "1.505"
+	o.Data.Payout.Methods[0].Fee = "10.125"
+
+	q, err := quote.FromOffering(pfiDID, r, o, time.Now().Add(time.Hour), quote.CurrencyPrecision("MXN", 1))
+	assert.NoError(t, err)
+
+	assert.Equal(t, quote.QuoteDetails{CurrencyCode: "USD", Subtotal: "100.02", Fee: "1.51", Total: "101.53"}, q.Data.Payin)
+	// 100.02 * 16.665 = 1666.833
+	assert.Equal(t, quote.QuoteDetails{CurrencyCode: "MXN", Subtotal: "1656.7", Fee: "10.1", Total: "1666.8"}, q.Data.Payout)
+}
+
+func TestFromOffering_PayinAmountPrecision(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	// the quote must not echo a different amount than the customer asked for
+	o, r := createOfferingAndRFQ(t, pfiDID, walletDID, "100.015")
+
+	_, err := quote.FromOffering(pfiDID, r, o, time.Now().Add(time.Hour))
+	assert.Error(t, err)
+
+	q, err := quote.FromOffering(pfiDID, r, o, time.Now().Add(time.Hour), quote.CurrencyPrecision("USD", 3))
+	assert.NoError(t, err)
+	assert.Equal(t, "100.015", q.Data.Payin.Subtotal)
+}
+
+// TestFromOffering_Totals asserts the QuoteDetails rule that every total is the subtotal plus the fee, as it is
+// for quotes created with NewQuoteDetails.
+func TestFromOffering_Totals(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, r := createOfferingAndRFQ(t, pfiDID, walletDID, "250")
+
+	q, err := quote.FromOffering(pfiDID, r, o, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	payin := q.Data.Payin
+	assert.Equal(t, decimal.RequireFromString(payin.Subtotal).Add(decimal.RequireFromString(payin.Fee)).String(), payin.Total)
+
+	payout := q.Data.Payout
+	assert.Equal(t, decimal.RequireFromString(payout.Subtotal).Add(decimal.RequireFromString(payout.Fee)).String(), payout.Total)
+}
+
+func TestFromOffering_InvalidFee(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, r := createOfferingAndRFQ(t, pfiDID, walletDID, "100")
+	o.Data.Payout.Methods[0].Fee = "ten"
+
+	_, err := quote.FromOffering(pfiDID, r, o, time.Now().Add(time.Hour))
+	assert.Error(t, err)
+}
+
+func TestFromOffering_PayoutFeeExceedsSubtotal(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, r := createOfferingAndRFQ(t, pfiDID, walletDID, "0.5")
+
+	_, err := quote.FromOffering(pfiDID, r, o, time.Now().Add(time.Hour))
+	assert.Error(t, err)
+}
+
+func TestFromOffering_UnsupportedMethod(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, _ := createOfferingAndRFQ(t, pfiDID, walletDID, "100")
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		o.Metadata.ID,
+		rfq.Payin("100", "DEBIT_CARD"),
+		rfq.Payout("BANK_ACCOUNT"),
+	)
+	assert.NoError(t, err)
+
+	_, err = quote.FromOffering(pfiDID, r, o, time.Now().Add(time.Hour))
+	assert.Error(t, err)
+}
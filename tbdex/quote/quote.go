@@ -1,15 +1,18 @@
 package quote
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/message"
 	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/shopspring/decimal"
 	"github.com/tbd54566975/web5-go/dids/did"
@@ -41,6 +44,11 @@ func (q Quote) GetKind() string {
 	return Kind
 }
 
+// GetSignature returns the signature of the message
+func (q Quote) GetSignature() string {
+	return q.Signature
+}
+
 // GetValidNext returns the valid message kinds that can follow a Quote.
 func (q Quote) GetValidNext() []string {
 	return ValidNext()
@@ -58,10 +66,10 @@ func (q Quote) IsValidNext(kind string) bool {
 
 // Data encapsulates the data content of a  quote.
 type Data struct {
-	ExpiresAt string       `json:"expiresAt,omitempty"`
-	Rate      string       `json:"payoutUnitsPerPayinUnit,omitempty"`
-	Payin     QuoteDetails `json:"payin,omitempty"`
-	Payout    QuoteDetails `json:"payout,omitempty"`
+	ExpiresAt timestamp.Timestamp `json:"expiresAt,omitempty"`
+	Rate      string              `json:"payoutUnitsPerPayinUnit,omitempty"`
+	Payin     QuoteDetails        `json:"payin,omitempty"`
+	Payout    QuoteDetails        `json:"payout,omitempty"`
 }
 
 // QuoteDetails describes the relevant information of a currency that is being sent or received
@@ -73,6 +81,15 @@ type QuoteDetails struct {
 }
 
 
+// ExpiresAtTime parses the time at which the quote expires.
+func (q Quote) ExpiresAtTime() (time.Time, error) {
+	if err := q.Data.ExpiresAt.Validate(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse quote expires at: %w", err)
+	}
+
+	return q.Data.ExpiresAt.Time(), nil
+}
+
 // Digest computes a hash of the quote
 func (q Quote) Digest() ([]byte, error) {
 	payload := map[string]any{"metadata": q.Metadata, "data": q.Data}
@@ -86,8 +103,13 @@ func (q Quote) Digest() ([]byte, error) {
 }
 
 // Verify verifies the signature of the quote.
-func (q *Quote) Verify() error {
-	decoded, err := crypto.VerifySignature(q, q.Signature)
+func (q Quote) Verify() error {
+	return q.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [Quote.Verify] but uses ctx when resolving the signer's DID.
+func (q Quote) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, q, q.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify quote signature: %w", err)
 	}
@@ -119,30 +141,47 @@ func (q *Quote) UnmarshalJSON(data []byte) error {
 
 // Parse validates, parses input data into an Quote, and verifies the signature.
 func Parse(data []byte) (Quote, error) {
+	return ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Parse] but uses ctx when verifying the signature.
+func ParseContext(ctx context.Context, data []byte) (Quote, error) {
 	q := Quote{}
 	if err := json.Unmarshal(data, &q); err != nil {
 		return Quote{}, fmt.Errorf("failed to unmarshal Quote: %w", err)
 	}
 
-	if err := q.Verify(); err != nil {
+	if err := q.VerifyContext(ctx); err != nil {
 		return Quote{}, fmt.Errorf("failed to verify Quote: %w", err)
 	}
 
 	return q, nil
 }
 
-// Create generates a new Quote with the specified parameters and options.
+// Create generates a new Quote with the specified parameters and options. expiresAt must be an RFC 3339 date-time.
 func Create(fromDID did.BearerDID, to, exchangeID, expiresAt string, rate string, payin, payout QuoteDetails, opts ...CreateOption) (Quote, error) {
 	q := createOptions{
-		id:        typeid.Must(typeid.WithPrefix(Kind)).String(),
-		createdAt: time.Now(),
-		protocol:  "1.0",
+		id:       typeid.Must(typeid.WithPrefix(Kind)).String(),
+		protocol: "1.0",
 	}
 
 	for _, opt := range opts {
 		opt(&q)
 	}
 
+	now := clock.OrDefault(q.clock).Now()
+	if q.createdAt.IsZero() {
+		q.createdAt = now
+	}
+
+	if !validator.SupportsProtocol(q.protocol) {
+		return Quote{}, fmt.Errorf("unsupported protocol: %s", q.protocol)
+	}
+
+	if err := timestamp.Timestamp(expiresAt).Validate(); err != nil {
+		return Quote{}, fmt.Errorf("invalid expires at: %w", err)
+	}
+
 	quote := Quote{
 		Metadata: message.Metadata{
 			From:       fromDID.URI,
@@ -150,12 +189,12 @@ func Create(fromDID did.BearerDID, to, exchangeID, expiresAt string, rate string
 			Kind:       Kind,
 			ID:         q.id,
 			ExchangeID: exchangeID,
-			CreatedAt:  q.createdAt.UTC().Format(time.RFC3339),
+			CreatedAt:  timestamp.New(q.createdAt),
 			ExternalID: q.externalID,
 			Protocol:   q.protocol,
 		},
 		Data: Data{
-			ExpiresAt: expiresAt,
+			ExpiresAt: timestamp.Timestamp(expiresAt),
 			Rate:      rate,
 			Payin:     payin,
 			Payout:    payout,
@@ -177,6 +216,7 @@ type createOptions struct {
 	createdAt  time.Time
 	protocol   string
 	externalID string
+	clock      clock.Clock
 }
 
 // CreateOption defines a type for functions that can modify the createOptions struct.
@@ -189,6 +229,13 @@ func ID(id string) CreateOption {
 	}
 }
 
+// Protocol can be passed to [Create] to set the protocol version. Defaults to "1.0".
+func Protocol(version string) CreateOption {
+	return func(o *createOptions) {
+		o.protocol = version
+	}
+}
+
 // CreatedAt can be passed to [Create] to provide a custom created at time.
 func CreatedAt(t time.Time) CreateOption {
 	return func(o *createOptions) {
@@ -196,6 +243,13 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at time. Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(o *createOptions) {
+		o.clock = c
+	}
+}
+
 // ExternalID can be passed to [Create] to provide a custom external id.
 func ExternalID(externalID string) CreateOption {
 	return func(o *createOptions) {
@@ -100,6 +100,27 @@ func TestUnmarshal_Invalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCreate_InvalidExpiresAt(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	rfqID, _ := typeid.WithPrefix(rfq.Kind)
+
+	for _, expiresAt := range []string{"", "tomorrow", "2024-05-01T09:00:00"} {
+		t.Run(expiresAt, func(t *testing.T) {
+			_, err := quote.Create(
+				pfiDID,
+				walletDID.URI,
+				rfqID.String(),
+				expiresAt,
+				"16.665",
+				quote.NewQuoteDetails("USD", decimal.RequireFromString("10")),
+				quote.NewQuoteDetails("MXN", decimal.RequireFromString("500")),
+			)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func TestVerify(t *testing.T) {
 	pfiDID, _ := didjwk.Create()
 	walletDID, _ := didjwk.Create()
@@ -0,0 +1,44 @@
+package quote
+
+import (
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+)
+
+type verifyOrderOptions struct {
+	clock clock.Clock
+	skew  time.Duration
+}
+
+// VerifyOrderOption implements functional options pattern for [Quote.VerifyOrder].
+type VerifyOrderOption func(*verifyOrderOptions)
+
+// OrderClock can be passed to [Quote.VerifyOrder] to provide the clock used to check whether the quote has
+// expired. Defaults to [clock.System].
+func OrderClock(c clock.Clock) VerifyOrderOption {
+	return func(o *verifyOrderOptions) {
+		o.clock = c
+	}
+}
+
+// Skew can be passed to [Quote.VerifyOrder] to tolerate clocks that are out of sync by up to d, accepting
+// orders up to d after the quote expired. Defaults to 0.
+func Skew(d time.Duration) VerifyOrderOption {
+	return func(o *verifyOrderOptions) {
+		o.skew = d
+	}
+}
+
+// VerifyOrder is used by PFIs to check that an incoming order can be accepted against the quote, which should
+// be the latest quote of the exchange. See [order.Order.CheckQuote] for the checks. Expiry failures wrap
+// [order.ErrQuoteExpired].
+func (q Quote) VerifyOrder(o order.Order, opts ...VerifyOrderOption) error {
+	options := verifyOrderOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return o.CheckQuote(q, clock.OrDefault(options.clock).Now(), options.skew)
+}
@@ -0,0 +1,67 @@
+package quote_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/quote"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/alecthomas/assert/v2"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+	"go.jetpack.io/typeid"
+)
+
+func TestVerifyOrder(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	exchangeID := typeid.Must(typeid.WithPrefix(rfq.Kind)).String()
+	expiresAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	q, err := quote.Create(
+		pfiDID,
+		walletDID.URI,
+		exchangeID,
+		expiresAt.Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+
+	parsed, err := q.ExpiresAtTime()
+	assert.NoError(t, err)
+	assert.True(t, expiresAt.Equal(parsed))
+
+	o, err := order.Create(walletDID, pfiDID.URI, exchangeID, order.CreatedAt(expiresAt.Add(-time.Minute)))
+	assert.NoError(t, err)
+
+	before := clock.Fixed(expiresAt.Add(-time.Second))
+	after := clock.Fixed(expiresAt.Add(10 * time.Second))
+
+	assert.NoError(t, q.VerifyOrder(o, quote.OrderClock(before)))
+
+	err = q.VerifyOrder(o, quote.OrderClock(after))
+	assert.True(t, errors.Is(err, order.ErrQuoteExpired))
+
+	assert.NoError(t, q.VerifyOrder(o, quote.OrderClock(after), quote.Skew(30*time.Second)))
+
+	// created after the quote expired, even though it arrived in time according to the clock
+	late, err := order.Create(walletDID, pfiDID.URI, exchangeID, order.CreatedAt(expiresAt.Add(time.Minute)))
+	assert.NoError(t, err)
+
+	err = q.VerifyOrder(late, quote.OrderClock(before))
+	assert.True(t, errors.Is(err, order.ErrQuoteExpired))
+
+	// sent by someone other than the quote's recipient
+	impostor, err := order.Create(pfiDID, pfiDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	err = q.VerifyOrder(impostor, quote.OrderClock(before))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, order.ErrQuoteExpired))
+}
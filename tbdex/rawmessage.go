@@ -0,0 +1,152 @@
+package tbdex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
+)
+
+// RawMessage is a [Message] that retains the JSON it was parsed from. Re-marshaling a parsed message can
+// reorder fields or drop zero values that the sender included, so RawMessage emits the original JSON verbatim
+// from MarshalJSON. This allows stores and forwarders to pass messages on without affecting their signatures.
+// For the same reason, the signature of a RawMessage is verified against the original JSON rather than the
+// typed message.
+//
+// RawMessage can be added to an [Exchange] like any other message.
+type RawMessage struct {
+	Message
+	raw json.RawMessage
+}
+
+// NewRawMessage wraps msg along with the JSON it was parsed from. The caller is responsible for raw being
+// the JSON msg was parsed from, use [ParseRawMessage] otherwise. If msg is a RawMessage its JSON is replaced.
+func NewRawMessage(msg Message, raw []byte) RawMessage {
+	return RawMessage{Message: unwrapMessage(msg), raw: bytes.Clone(raw)}
+}
+
+// UnmarshalRawMessage is like [UnmarshalMessage] but retains the input.
+func UnmarshalRawMessage(input []byte) (RawMessage, error) {
+	msg, err := UnmarshalMessage(input)
+	if err != nil {
+		return RawMessage{}, err
+	}
+
+	return NewRawMessage(msg, input), nil
+}
+
+// ParseRawMessage is like [ParseMessage] but retains the input.
+func ParseRawMessage(input []byte) (RawMessage, error) {
+	return ParseRawMessageContext(context.Background(), input)
+}
+
+// ParseRawMessageContext is like [ParseMessageContext] but retains the input. The signature is verified
+// against the input, see [RawMessage.Digest].
+func ParseRawMessageContext(ctx context.Context, input []byte) (RawMessage, error) {
+	msg, err := UnmarshalRawMessage(input)
+	if err != nil {
+		return RawMessage{}, err
+	}
+
+	if err := msg.VerifyContext(ctx); err != nil {
+		return RawMessage{}, fmt.Errorf("failed to verify %s: %w", msg.GetKind(), err)
+	}
+
+	return msg, nil
+}
+
+// Raw returns a copy of the JSON the message was parsed from.
+func (m RawMessage) Raw() json.RawMessage {
+	return bytes.Clone(m.raw)
+}
+
+// Unwrap returns the typed message.
+func (m RawMessage) Unwrap() Message {
+	return m.Message
+}
+
+// Digest computes a hash of the metadata and data as found in the JSON the message was parsed from, which
+// includes any fields the typed message drops. The typed message's digest is used if there is no JSON.
+func (m RawMessage) Digest() ([]byte, error) {
+	if m.raw == nil {
+		return m.Message.Digest()
+	}
+
+	var parts struct {
+		Metadata json.RawMessage `json:"metadata"`
+		Data     json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(m.raw, &parts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal raw message: %w", err)
+	}
+
+	hashed, err := crypto.DigestJSON(map[string]any{"metadata": parts.Metadata, "data": parts.Data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to digest raw message: %w", err)
+	}
+
+	return hashed, nil
+}
+
+// Verify verifies the signature of the message against [RawMessage.Digest].
+func (m RawMessage) Verify() error {
+	return m.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [RawMessage.Verify] but uses ctx when resolving the signer's DID.
+func (m RawMessage) VerifyContext(ctx context.Context) error {
+	if m.raw == nil {
+		return m.Message.VerifyContext(ctx)
+	}
+
+	decoded, err := crypto.VerifySignatureContext(ctx, m, m.GetSignature())
+	if err != nil {
+		return fmt.Errorf("failed to verify %s signature: %w", m.GetKind(), err)
+	}
+
+	if from := m.GetMetadata().From; decoded.SignerDID.URI != from {
+		return fmt.Errorf("signer: %s does not match message metadata from: %s", decoded.SignerDID.URI, from)
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the JSON the message was parsed from. The typed message is marshaled if there is none.
+// Note that encoding/json compacts the output of MarshalJSON, which doesn't affect the message's digest.
+// Use [RawMessage.Raw] to obtain the exact bytes.
+func (m RawMessage) MarshalJSON() ([]byte, error) {
+	if m.raw == nil {
+		if m.Message == nil {
+			return nil, errors.New("cannot marshal empty raw message")
+		}
+
+		return json.Marshal(m.Message)
+	}
+
+	return bytes.Clone(m.raw), nil
+}
+
+// UnmarshalJSON validates and unmarshals the message and retains the input. The signature is not verified.
+func (m *RawMessage) UnmarshalJSON(data []byte) error {
+	raw, err := UnmarshalRawMessage(data)
+	if err != nil {
+		return err
+	}
+
+	*m = raw
+
+	return nil
+}
+
+// unwrapMessage returns the typed message if m is a [RawMessage].
+func unwrapMessage(m Message) Message {
+	if raw, ok := m.(RawMessage); ok {
+		return raw.Message
+	}
+
+	return m
+}
@@ -0,0 +1,146 @@
+package tbdex_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
+	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+// reorder reorders the fields of the message so that it differs byte-wise from a re-marshaled message.
+func reorder(t *testing.T, msg tbdex.Message) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(msg)
+	assert.NoError(t, err)
+
+	var generic map[string]any
+	assert.NoError(t, json.Unmarshal(data, &generic))
+
+	data, err = json.Marshal(generic)
+	assert.NoError(t, err)
+
+	return data
+}
+
+func TestParseRawMessage(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	input := reorder(t, createRFQ(t, walletDID, pfiDID))
+
+	msg, err := tbdex.ParseRawMessage(input)
+	assert.NoError(t, err)
+	assert.Equal(t, string(input), string(msg.Raw()))
+
+	_, ok := msg.Unwrap().(rfq.RFQ)
+	assert.True(t, ok)
+
+	output, err := json.Marshal(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, string(input), string(output))
+
+	typed, err := json.Marshal(msg.Unwrap())
+	assert.NoError(t, err)
+	assert.NotEqual(t, string(input), string(typed))
+
+	assert.NoError(t, msg.Verify())
+}
+
+func TestRawMessage_Exchange(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+	q := createQuote(t, pfiDID, walletDID.URI, r.Metadata.ExchangeID)
+
+	var messages []tbdex.Message
+	for _, m := range []tbdex.Message{r, q} {
+		raw, err := tbdex.ParseRawMessage(reorder(t, m))
+		assert.NoError(t, err)
+		messages = append(messages, raw)
+	}
+
+	exchange, err := tbdex.NewExchange(messages...)
+	assert.NoError(t, err)
+	assert.Equal(t, r.Metadata.ID, exchange.RFQ().Metadata.ID)
+
+	data, err := json.Marshal(exchange.Messages())
+	assert.NoError(t, err)
+
+	var decoded []tbdex.RawMessage
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, 2, len(decoded))
+
+	for i, m := range decoded {
+		assert.Equal(t, string(messages[i].(tbdex.RawMessage).Raw()), string(m.Raw()))
+		assert.NoError(t, m.Verify())
+	}
+}
+
+func TestNewRawMessage_Nested(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+	input := reorder(t, r)
+
+	msg := tbdex.NewRawMessage(tbdex.NewRawMessage(r, nil), input)
+
+	_, ok := msg.Unwrap().(rfq.RFQ)
+	assert.True(t, ok)
+	assert.Equal(t, string(input), string(msg.Raw()))
+}
+
+type jsonPayload map[string]any
+
+func (p jsonPayload) Digest() ([]byte, error) {
+	return crypto.DigestJSON(p)
+}
+
+func TestParseRawMessage_CloseNotSuccessful(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+
+	c, err := closemsg.Create(pfiDID, "did:example:alice", "rfq_01hwztehxhe139magy0a18mzms", closemsg.Reason("done"))
+	assert.NoError(t, err)
+
+	// other implementations sign success:false, which the typed close omits
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+
+	var generic map[string]any
+	assert.NoError(t, json.Unmarshal(data, &generic))
+	generic["data"] = map[string]any{"reason": "done", "success": false}
+
+	generic["signature"], err = crypto.Sign(jsonPayload{"metadata": generic["metadata"], "data": generic["data"]}, pfiDID)
+	assert.NoError(t, err)
+
+	input, err := json.Marshal(generic)
+	assert.NoError(t, err)
+
+	_, err = tbdex.ParseMessage(input)
+	assert.Error(t, err)
+
+	msg, err := tbdex.ParseRawMessage(input)
+	assert.NoError(t, err)
+
+	output, err := json.Marshal(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, string(input), string(output))
+
+	var decoded tbdex.RawMessage
+	assert.NoError(t, json.Unmarshal(output, &decoded))
+	assert.NoError(t, decoded.Verify())
+
+	generic["data"] = map[string]any{"reason": "done", "success": true}
+	tampered, err := json.Marshal(generic)
+	assert.NoError(t, err)
+
+	_, err = tbdex.ParseRawMessage(tampered)
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package resolver
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/tbd54566975/web5-go/dids/didcore"
+)
+
+// Defaults used by [NewCache].
+const (
+	DefaultCacheTTL         = 15 * time.Minute
+	DefaultCacheNegativeTTL = 30 * time.Second
+	DefaultCacheMaxEntries  = 1000
+)
+
+// Cache is a [Resolver] that caches the results of another resolver. Successful resolutions are cached
+// for the TTL and failed resolutions for the negative TTL. Once the cache holds its maximum number of
+// entries the least recently used entry is evicted. Failures caused by the context being canceled or
+// exceeding its deadline are never cached. Cache is safe for concurrent use.
+type Cache struct {
+	next        Resolver
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxEntries  int
+	clock       clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	uri       string
+	result    didcore.ResolutionResult
+	err       error
+	expiresAt time.Time
+}
+
+// CacheOption implements functional options pattern for [NewCache].
+type CacheOption func(*Cache)
+
+// TTL sets how long successful resolutions are cached. Defaults to [DefaultCacheTTL].
+func TTL(ttl time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.ttl = ttl
+	}
+}
+
+// NegativeTTL sets how long failed resolutions are cached. A TTL of 0 disables negative caching.
+// Defaults to [DefaultCacheNegativeTTL].
+func NegativeTTL(ttl time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.negativeTTL = ttl
+	}
+}
+
+// MaxEntries sets the maximum number of cached resolutions. Defaults to [DefaultCacheMaxEntries].
+func MaxEntries(n int) CacheOption {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+// Clock sets the clock used to expire cached resolutions. Defaults to the clock carried by the context passed
+// to [Cache.Resolve], see [clock.FromContext].
+func Clock(c clock.Clock) CacheOption {
+	return func(cache *Cache) {
+		cache.clock = c
+	}
+}
+
+// NewCache creates a [Cache] in front of the given resolver.
+func NewCache(next Resolver, opts ...CacheOption) *Cache {
+	c := &Cache{
+		next:        next,
+		ttl:         DefaultCacheTTL,
+		negativeTTL: DefaultCacheNegativeTTL,
+		maxEntries:  DefaultCacheMaxEntries,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Resolve returns the cached resolution for the given DID, resolving it with the underlying resolver
+// if it is not cached or has expired.
+func (c *Cache) Resolve(ctx context.Context, uri string) (didcore.ResolutionResult, error) {
+	clk := c.clock
+	if clk == nil {
+		clk = clock.FromContext(ctx)
+	}
+
+	if entry, ok := c.get(uri, clk.Now()); ok {
+		return entry.result, entry.err
+	}
+
+	result, err := c.next.Resolve(ctx, uri)
+
+	ttl := c.ttl
+	if err != nil {
+		ttl = c.negativeTTL
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			ttl = 0
+		}
+	}
+
+	if ttl > 0 {
+		c.put(cacheEntry{uri: uri, result: result, err: err, expiresAt: clk.Now().Add(ttl)})
+	}
+
+	return result, err
+}
+
+// Purge removes the given DID from the cache. If no DIDs are provided, all entries are removed.
+func (c *Cache) Purge(uris ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(uris) == 0 {
+		c.entries = make(map[string]*list.Element)
+		c.lru.Init()
+		return
+	}
+
+	for _, uri := range uris {
+		if el, ok := c.entries[uri]; ok {
+			c.lru.Remove(el)
+			delete(c.entries, uri)
+		}
+	}
+}
+
+// Len returns the number of cached entries, including expired entries that have not been evicted yet.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *Cache) get(uri string, now time.Time) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[uri]
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	entry := el.Value.(cacheEntry)
+	if !now.Before(entry.expiresAt) {
+		c.lru.Remove(el)
+		delete(c.entries, uri)
+		return cacheEntry{}, false
+	}
+
+	c.lru.MoveToFront(el)
+
+	return entry, true
+}
+
+func (c *Cache) put(entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[entry.uri]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[entry.uri] = c.lru.PushFront(entry)
+
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheEntry).uri)
+	}
+}
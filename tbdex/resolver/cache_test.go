@@ -0,0 +1,165 @@
+package resolver_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
+	"github.com/TBD54566975/tbdex-go/tbdex/resolver"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didcore"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+type countingResolver struct {
+	next  resolver.Resolver
+	calls atomic.Int32
+}
+
+func (r *countingResolver) Resolve(ctx context.Context, uri string) (didcore.ResolutionResult, error) {
+	r.calls.Add(1)
+	return r.next.Resolve(ctx, uri)
+}
+
+func TestCache(t *testing.T) {
+	bearerDID, _ := didjwk.Create()
+	next := &countingResolver{next: resolver.NewStatic(bearerDID.Document)}
+
+	cache := resolver.NewCache(next)
+
+	for range 3 {
+		result, err := cache.Resolve(context.Background(), bearerDID.URI)
+		assert.NoError(t, err)
+		assert.Equal(t, bearerDID.URI, result.Document.ID)
+	}
+
+	assert.Equal(t, int32(1), next.calls.Load())
+
+	cache.Purge(bearerDID.URI)
+	_, err := cache.Resolve(context.Background(), bearerDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), next.calls.Load())
+}
+
+func TestCache_TTL(t *testing.T) {
+	bearerDID, _ := didjwk.Create()
+	next := &countingResolver{next: resolver.NewStatic(bearerDID.Document)}
+
+	now := time.Now()
+	clk := clock.Func(func() time.Time { return now })
+
+	cache := resolver.NewCache(next, resolver.TTL(time.Minute), resolver.Clock(clk))
+
+	_, err := cache.Resolve(context.Background(), bearerDID.URI)
+	assert.NoError(t, err)
+
+	now = now.Add(59 * time.Second)
+	_, err = cache.Resolve(context.Background(), bearerDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), next.calls.Load())
+
+	now = now.Add(time.Second)
+	_, err = cache.Resolve(context.Background(), bearerDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), next.calls.Load())
+}
+
+func TestCache_ContextClock(t *testing.T) {
+	bearerDID, _ := didjwk.Create()
+	next := &countingResolver{next: resolver.NewStatic(bearerDID.Document)}
+
+	cache := resolver.NewCache(next, resolver.TTL(time.Minute))
+
+	_, err := cache.Resolve(context.Background(), bearerDID.URI)
+	assert.NoError(t, err)
+
+	ctx := clock.NewContext(context.Background(), clock.Fixed(time.Now().Add(time.Hour)))
+	_, err = cache.Resolve(ctx, bearerDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), next.calls.Load())
+}
+
+func TestCache_Negative(t *testing.T) {
+	static := resolver.NewStatic()
+	next := &countingResolver{next: static}
+
+	cache := resolver.NewCache(next, resolver.NegativeTTL(time.Hour))
+
+	_, err := cache.Resolve(context.Background(), "did:example:alice")
+	assert.Error(t, err)
+
+	// the failure is cached even though the document is now available
+	static.Add(didcore.Document{ID: "did:example:alice"})
+	_, err = cache.Resolve(context.Background(), "did:example:alice")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), next.calls.Load())
+
+	disabled := resolver.NewCache(next, resolver.NegativeTTL(0))
+	_, err = disabled.Resolve(context.Background(), "did:example:bob")
+	assert.Error(t, err)
+	assert.Equal(t, 0, disabled.Len())
+}
+
+func TestCache_ContextErrorsNotCached(t *testing.T) {
+	next := &countingResolver{next: resolver.Func(func(ctx context.Context, uri string) (didcore.ResolutionResult, error) {
+		return didcore.ResolutionResult{}, ctx.Err()
+	})}
+
+	cache := resolver.NewCache(next)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := cache.Resolve(ctx, "did:example:alice")
+	assert.IsError(t, err, context.Canceled)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCache_MaxEntries(t *testing.T) {
+	alice, _ := didjwk.Create()
+	bob, _ := didjwk.Create()
+	carol, _ := didjwk.Create()
+	next := &countingResolver{next: resolver.NewStatic(alice.Document, bob.Document, carol.Document)}
+
+	cache := resolver.NewCache(next, resolver.MaxEntries(2))
+
+	for _, uri := range []string{alice.URI, bob.URI, alice.URI, carol.URI} {
+		_, err := cache.Resolve(context.Background(), uri)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, int32(3), next.calls.Load())
+
+	// bob was the least recently used entry and has been evicted
+	_, err := cache.Resolve(context.Background(), alice.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), next.calls.Load())
+
+	_, err = cache.Resolve(context.Background(), bob.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), next.calls.Load())
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	bearerDID, _ := didjwk.Create()
+	cache := resolver.NewCache(resolver.NewStatic(bearerDID.Document), resolver.MaxEntries(1))
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_, err := cache.Resolve(context.Background(), bearerDID.URI)
+				assert.NoError(t, err)
+				_, _ = cache.Resolve(context.Background(), "did:example:unknown")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
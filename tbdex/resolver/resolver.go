@@ -0,0 +1,93 @@
+// Package resolver provides the DID resolution used to verify the signatures of tbDEX messages and resources.
+//
+// By default DIDs are resolved using the DID methods implemented in web5-go, some of which require network
+// requests. [SetDefault] can be used to replace the default, e.g. with a [Cache] to avoid resolving the same
+// DID repeatedly, or with a [Static] resolver in tests and air-gapped deployments.
+package resolver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tbd54566975/web5-go/dids"
+	"github.com/tbd54566975/web5-go/dids/didcore"
+)
+
+// Resolver resolves a DID URI into a DID Document.
+type Resolver interface {
+	Resolve(ctx context.Context, uri string) (didcore.ResolutionResult, error)
+}
+
+// Func is an adapter that allows the use of ordinary functions as a [Resolver].
+type Func func(ctx context.Context, uri string) (didcore.ResolutionResult, error)
+
+// Resolve calls f(ctx, uri).
+func (f Func) Resolve(ctx context.Context, uri string) (didcore.ResolutionResult, error) {
+	return f(ctx, uri)
+}
+
+// Network resolves DIDs using the DID methods implemented in web5-go.
+var Network Resolver = Func(dids.ResolveWithContext)
+
+var (
+	defaultMu       sync.RWMutex
+	defaultResolver = Network
+)
+
+// Default returns the resolver used to verify signatures when no resolver is provided explicitly.
+func Default() Resolver {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+
+	return defaultResolver
+}
+
+// SetDefault replaces the resolver returned by [Default]. Passing nil restores [Network].
+func SetDefault(r Resolver) {
+	if r == nil {
+		r = Network
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	defaultResolver = r
+}
+
+// Static resolves DIDs from a fixed set of DID Documents without making any network requests.
+// Resolving a DID that has not been added results in a "notFound" [didcore.ResolutionError].
+type Static struct {
+	mu   sync.RWMutex
+	docs map[string]didcore.Document
+}
+
+// NewStatic creates a [Static] resolver for the given DID Documents.
+func NewStatic(docs ...didcore.Document) *Static {
+	s := &Static{docs: make(map[string]didcore.Document, len(docs))}
+	s.Add(docs...)
+
+	return s
+}
+
+// Add adds DID Documents to the resolver, replacing any documents with the same id.
+func (s *Static) Add(docs ...didcore.Document) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range docs {
+		s.docs[doc.ID] = doc
+	}
+}
+
+// Resolve returns the DID Document with the given id.
+func (s *Static) Resolve(_ context.Context, uri string) (didcore.ResolutionResult, error) {
+	s.mu.RLock()
+	doc, ok := s.docs[uri]
+	s.mu.RUnlock()
+
+	if !ok {
+		return didcore.ResolutionResultWithError("notFound"), didcore.ResolutionError{Code: "notFound"}
+	}
+
+	return didcore.ResolutionResultWithDocument(doc), nil
+}
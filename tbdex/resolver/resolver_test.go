@@ -0,0 +1,45 @@
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/resolver"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didcore"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func TestStatic(t *testing.T) {
+	bearerDID, _ := didjwk.Create()
+
+	static := resolver.NewStatic(bearerDID.Document)
+
+	result, err := static.Resolve(context.Background(), bearerDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, bearerDID.Document, result.Document)
+
+	_, err = static.Resolve(context.Background(), "did:example:unknown")
+	var resolutionErr didcore.ResolutionError
+	assert.True(t, errors.As(err, &resolutionErr))
+	assert.Equal(t, "notFound", resolutionErr.Code)
+
+	other, _ := didjwk.Create()
+	static.Add(other.Document)
+
+	result, err = static.Resolve(context.Background(), other.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, other.URI, result.Document.ID)
+}
+
+func TestSetDefault(t *testing.T) {
+	assert.Equal[resolver.Resolver](t, resolver.Network, resolver.Default())
+
+	static := resolver.NewStatic()
+	resolver.SetDefault(static)
+	assert.Equal[resolver.Resolver](t, static, resolver.Default())
+
+	resolver.SetDefault(nil)
+	assert.Equal[resolver.Resolver](t, resolver.Network, resolver.Default())
+}
@@ -0,0 +1,85 @@
+package tbdex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	libbalance "github.com/TBD54566975/tbdex-go/tbdex/balance"
+	liboffering "github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/resource"
+)
+
+// Resource is the interface that all tbdex resources implement. Especially useful for decoding and parsing resources
+// when the kind of resource is not known upfront.
+type Resource interface {
+	Digest() ([]byte, error)
+	GetKind() string
+	GetMetadata() resource.Metadata
+	GetSignature() string
+	Verify() error
+	VerifyContext(ctx context.Context) error
+}
+
+// rsrc only decodes the kind so that any other invalid metadata, such as a malformed createdAt, is reported by
+// the validation of the resource itself.
+type rsrc struct {
+	Metadata struct {
+		Kind string `json:"kind"`
+	} `json:"metadata"`
+}
+
+// UnmarshalResource unmarshals a resource. It uses the metadata kind to determine the type of resource.
+//
+// # Note
+//
+// unmarshaling includes validation
+func UnmarshalResource(input []byte) (Resource, error) {
+	var r rsrc
+	if err := json.Unmarshal(input, &r); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal partial resource to determine kind: %w", err)
+	}
+
+	switch r.Metadata.Kind {
+	case liboffering.Kind:
+		var offering liboffering.Offering
+		if err := json.Unmarshal(input, &offering); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal offering: %w", err)
+		}
+
+		return offering, nil
+	case libbalance.Kind:
+		var balance libbalance.Balance
+		if err := json.Unmarshal(input, &balance); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal balance: %w", err)
+		}
+
+		return balance, nil
+	default:
+		return nil, fmt.Errorf("unknown resource kind: %v", r.Metadata.Kind)
+	}
+}
+
+// ParseResource parses a resource. It uses the metadata kind to determine the type of resource.
+//
+// # Note
+//
+// parsing validates the resource and verifies the integrity of the resource which can lead to
+// a network request in order to resolve the signer's DID
+func ParseResource(input []byte) (Resource, error) {
+	return ParseResourceContext(context.Background(), input)
+}
+
+// ParseResourceContext is like [ParseResource] but uses ctx when verifying the resource's signature.
+func ParseResourceContext(ctx context.Context, input []byte) (Resource, error) {
+	r, err := UnmarshalResource(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.VerifyContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to verify %s: %w", r.GetKind(), err)
+	}
+
+	return r, nil
+}
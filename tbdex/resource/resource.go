@@ -1,11 +1,13 @@
 package resource
 
+import "github.com/TBD54566975/tbdex-go/tbdex/timestamp"
+
 // Metadata represents the metadata of a resource e.g. offering, balance etc.
 type Metadata struct {
-	From      string `json:"from"`
-	Kind      string `json:"kind"`
-	ID        string `json:"id"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt,omitempty"`
-	Protocol  string `json:"protocol"`
+	From      string              `json:"from"`
+	Kind      string              `json:"kind"`
+	ID        string              `json:"id"`
+	CreatedAt timestamp.Timestamp `json:"createdAt"`
+	UpdatedAt timestamp.Timestamp `json:"updatedAt,omitempty"`
+	Protocol  string              `json:"protocol"`
 }
@@ -0,0 +1,66 @@
+package tbdex_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/balance"
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func TestParseResource(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")}),
+		offering.NewPayout("MXN", []offering.PayoutMethod{offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour)}),
+		"16.665",
+		offering.NewCancellationDetails(false),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+
+	b, err := balance.Create(pfiDID, "USD", "100")
+	assert.NoError(t, err)
+
+	for _, want := range []tbdex.Resource{o, b} {
+		t.Run(want.GetKind(), func(t *testing.T) {
+			data, err := json.Marshal(want)
+			assert.NoError(t, err)
+
+			r, err := tbdex.ParseResource(data)
+			assert.NoError(t, err)
+			assert.Equal(t, want, r)
+			assert.Equal(t, want.GetSignature(), r.GetSignature())
+			assert.Equal(t, pfiDID.URI, r.GetMetadata().From)
+		})
+	}
+}
+
+func TestParseResource_InvalidSignature(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+
+	b, err := balance.Create(pfiDID, "USD", "100")
+	assert.NoError(t, err)
+
+	b.Data.Available = "1000000"
+
+	data, err := json.Marshal(b)
+	assert.NoError(t, err)
+
+	r, err := tbdex.UnmarshalResource(data)
+	assert.NoError(t, err)
+	assert.Error(t, r.Verify())
+
+	_, err = tbdex.ParseResource(data)
+	assert.Error(t, err)
+}
+
+func TestUnmarshalResource_UnknownKind(t *testing.T) {
+	_, err := tbdex.UnmarshalResource([]byte(`{"metadata":{"kind":"reputation"}}`))
+	assert.Error(t, err)
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/TBD54566975/tbdex-go/tbdex/clock"
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/message"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
+	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 
 	web5crypto "github.com/tbd54566975/web5-go/crypto"
 	"github.com/tbd54566975/web5-go/dids/did"
@@ -20,34 +23,54 @@ import (
 // [RFQ]: https://github.com/TBD54566975/tbdex/tree/main/specs/protocol#rfq-request-for-quote
 func Create(fromDID did.BearerDID, to, offeringID string, payin PayinMethod, payout PayoutMethod, opts ...CreateOption) (RFQ, error) {
 	r := createOptions{
-		id:        typeid.Must(typeid.WithPrefix(Kind)).String(),
-		createdAt: time.Now(),
-		protocol:  "1.0",
+		id: typeid.Must(typeid.WithPrefix(Kind)).String(),
 	}
 
 	for _, opt := range opts {
 		opt(&r)
 	}
 
-	randomBytes, err := web5crypto.GenerateEntropy(web5crypto.Entropy128)
+	now := clock.OrDefault(r.clock).Now()
+	if r.createdAt.IsZero() {
+		r.createdAt = now
+	}
+
+	if r.protocol == "" {
+		r.protocol = validator.DefaultProtocol
+	}
+
+	if !validator.SupportsProtocol(r.protocol) {
+		return RFQ{}, fmt.Errorf("unsupported protocol: %s", r.protocol)
+	}
+
+	salt, err := generateSalt()
 	if err != nil {
 		return RFQ{}, err
 	}
 
-	salt := base64.RawURLEncoding.EncodeToString(randomBytes)
+	// when using per field salts, every field is hashed with its own salt
+	salts := Salts{Claims: salt, Payin: salt, Payout: salt}
+	if r.perFieldSalts {
+		for _, s := range []*string{&salts.Claims, &salts.Payin, &salts.Payout} {
+			if *s, err = generateSalt(); err != nil {
+				return RFQ{}, err
+			}
+		}
+	}
+
 	privateData := PrivateData{}
 
-	scrubbedPayin, err := payin.Scrub(salt, &privateData)
+	scrubbedPayin, err := payin.Scrub(salts.Payin, &privateData)
 	if err != nil {
 		return RFQ{}, fmt.Errorf("failed to scrub payin: %w", err)
 	}
 
-	scrubbedPayout, err := payout.Scrub(salt, &privateData)
+	scrubbedPayout, err := payout.Scrub(salts.Payout, &privateData)
 	if err != nil {
 		return RFQ{}, fmt.Errorf("failed to scrub payout: %w", err)
 	}
 
-	scrubbedClaims, err := r.claims.Scrub(salt, &privateData)
+	scrubbedClaims, err := r.claims.Scrub(salts.Claims, &privateData)
 	if err != nil {
 		return RFQ{}, fmt.Errorf("failed to scrub claims: %w", err)
 	}
@@ -59,7 +82,7 @@ func Create(fromDID did.BearerDID, to, offeringID string, payin PayinMethod, pay
 			Kind:       Kind,
 			ID:         r.id,
 			ExchangeID: r.id,
-			CreatedAt:  r.createdAt.UTC().Format(time.RFC3339),
+			CreatedAt:  timestamp.New(r.createdAt),
 			ExternalID: r.externalID,
 			Protocol:   r.protocol,
 		},
@@ -72,7 +95,21 @@ func Create(fromDID did.BearerDID, to, offeringID string, payin PayinMethod, pay
 	}
 
 	if !privateData.IsZero() {
+		// the spec requires a salt even if every field has its own
 		privateData.Salt = salt
+		if r.perFieldSalts {
+			privateData.Salts = &Salts{}
+			if scrubbedClaims != "" {
+				privateData.Salts.Claims = salts.Claims
+			}
+			if scrubbedPayin.PaymentDetailsHash != "" {
+				privateData.Salts.Payin = salts.Payin
+			}
+			if scrubbedPayout.PaymentDetailsHash != "" {
+				privateData.Salts.Payout = salts.Payout
+			}
+		}
+
 		rfq.PrivateData = &privateData
 	}
 
@@ -87,11 +124,13 @@ func Create(fromDID did.BearerDID, to, offeringID string, payin PayinMethod, pay
 }
 
 type createOptions struct {
-	id         string
-	createdAt  time.Time
-	protocol   string
-	externalID string
-	claims     ClaimsSet
+	id            string
+	createdAt     time.Time
+	protocol      string
+	externalID    string
+	claims        ClaimsSet
+	perFieldSalts bool
+	clock         clock.Clock
 }
 
 // CreateOption is a function type used to apply options to RFQ creation.
@@ -104,6 +143,13 @@ func ID(id string) CreateOption {
 	}
 }
 
+// Protocol can be passed to [Create] to set the protocol version. Defaults to [validator.DefaultProtocol].
+func Protocol(version string) CreateOption {
+	return func(r *createOptions) {
+		r.protocol = version
+	}
+}
+
 // CreatedAt can be passed to [Create] to provide a custom created at time.
 func CreatedAt(t time.Time) CreateOption {
 	return func(r *createOptions) {
@@ -111,6 +157,13 @@ func CreatedAt(t time.Time) CreateOption {
 	}
 }
 
+// Clock can be passed to [Create] to provide the clock used for the default created at time. Defaults to [clock.System].
+func Clock(c clock.Clock) CreateOption {
+	return func(r *createOptions) {
+		r.clock = c
+	}
+}
+
 // ExternalID can be passed to [Create] to provide a custom external id.
 func ExternalID(externalID string) CreateOption {
 	return func(r *createOptions) {
@@ -125,6 +178,16 @@ func Claims(claims []string) CreateOption {
 	}
 }
 
+// NonStandardPerFieldSalts can be passed to [Create] in order to hash each private field with its own salt, which
+// allows disclosing one field without weakening the hiding of the others. The salts are sent in
+// [PrivateData.Salts], which is not part of the tbdex spec, so this option should only be used with PFIs known
+// to support it. Other PFIs will fail to verify the private data.
+func NonStandardPerFieldSalts() CreateOption {
+	return func(r *createOptions) {
+		r.perFieldSalts = true
+	}
+}
+
 type paymentMethodOptions struct {
 	details map[string]any
 }
@@ -167,6 +230,16 @@ func Payout(kind string, opts ...PaymentMethodOption) PayoutMethod {
 	return s
 }
 
+// generateSalt generates a random salt used to hash private data.
+func generateSalt() (string, error) {
+	randomBytes, err := web5crypto.GenerateEntropy(web5crypto.Entropy128)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
 func computeHash(salt string, data any) (string, error) {
 	byteArray, err := crypto.DigestJSON([]any{salt, data})
 	if err != nil {
@@ -0,0 +1,102 @@
+package rfq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
+)
+
+// DisclosureField identifies a field of an RFQ's private data that can be disclosed.
+type DisclosureField string
+
+const (
+	DiscloseClaims DisclosureField = "claims" // DiscloseClaims discloses the claims
+	DisclosePayin  DisclosureField = "payin"  // DisclosePayin discloses the payin payment details
+	DisclosePayout DisclosureField = "payout" // DisclosePayout discloses the payout payment details
+)
+
+// Disclosure contains a single field of an RFQ's private data along with the signed RFQ without private data.
+// It can be handed to a third party, who can use [Disclosure.Verify] to check that the field is the one the
+// customer committed to in the signed RFQ without learning the rest of the private data.
+type Disclosure struct {
+	Field DisclosureField `json:"field"`
+	Salt  string          `json:"salt"`
+	Value any             `json:"value"`
+	RFQ   RFQ             `json:"rfq"`
+}
+
+// Disclose verifies the RFQ's private data and creates a [Disclosure] for the given field.
+func (r RFQ) Disclose(field DisclosureField) (Disclosure, error) {
+	if err := r.verifyPrivateData(); err != nil {
+		return Disclosure{}, fmt.Errorf("failed to verify private data: %w", err)
+	}
+
+	var value any
+	switch field {
+	case DiscloseClaims:
+		if r.Data.ClaimsHash == "" {
+			return Disclosure{}, errors.New("rfq does not contain claims")
+		}
+		value = r.PrivateData.Claims
+	case DisclosePayin:
+		if r.Data.Payin.PaymentDetailsHash == "" {
+			return Disclosure{}, errors.New("rfq does not contain payin details")
+		}
+		value = r.PrivateData.Payin.PaymentDetails
+	case DisclosePayout:
+		if r.Data.Payout.PaymentDetailsHash == "" {
+			return Disclosure{}, errors.New("rfq does not contain payout details")
+		}
+		value = r.PrivateData.Payout.PaymentDetails
+	default:
+		return Disclosure{}, fmt.Errorf("unknown disclosure field: %s", field)
+	}
+
+	return Disclosure{
+		Field: field,
+		Salt:  r.PrivateData.saltFor(field),
+		Value: value,
+		RFQ:   RFQ{Metadata: r.Metadata, Data: r.Data, Signature: r.Signature},
+	}, nil
+}
+
+// Verify verifies the signature of the disclosed RFQ and that the disclosed value matches the
+// corresponding hash within the RFQ.
+func (d Disclosure) Verify() error {
+	return d.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [Disclosure.Verify] but uses ctx when resolving the signer's DID.
+func (d Disclosure) VerifyContext(ctx context.Context) error {
+	if d.RFQ.PrivateData != nil {
+		return errors.New("disclosed rfq must not contain private data")
+	}
+
+	if err := d.RFQ.VerifyContext(ctx); err != nil {
+		return fmt.Errorf("failed to verify disclosed rfq: %w", err)
+	}
+
+	var hash string
+	switch d.Field {
+	case DiscloseClaims:
+		hash = d.RFQ.Data.ClaimsHash
+	case DisclosePayin:
+		hash = d.RFQ.Data.Payin.PaymentDetailsHash
+	case DisclosePayout:
+		hash = d.RFQ.Data.Payout.PaymentDetailsHash
+	default:
+		return fmt.Errorf("unknown disclosure field: %s", d.Field)
+	}
+
+	if hash == "" {
+		return fmt.Errorf("disclosed rfq does not contain a %s hash", d.Field)
+	}
+
+	if err := crypto.VerifyDigest(hash, []any{d.Salt, d.Value}); err != nil {
+		return fmt.Errorf("failed to verify disclosed %s: %w", d.Field, err)
+	}
+
+	return nil
+}
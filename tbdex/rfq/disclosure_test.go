@@ -0,0 +1,69 @@
+package rfq_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func TestDisclose(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.Payin("100", "DEBIT_CARD", rfq.PaymentDetails(map[string]any{"cardNumber": "0123456789012345"})),
+		rfq.Payout("BANK_ACCOUNT", rfq.PaymentDetails(map[string]any{"accountNumber": "1234567890", "routingNumber": 123456789})),
+		rfq.Claims([]string{"claim"}),
+	)
+	assert.NoError(t, err)
+
+	for _, field := range []rfq.DisclosureField{rfq.DiscloseClaims, rfq.DisclosePayin, rfq.DisclosePayout} {
+		t.Run(string(field), func(t *testing.T) {
+			disclosure, err := r.Disclose(field)
+			assert.NoError(t, err)
+			assert.Zero(t, disclosure.RFQ.PrivateData)
+
+			data, err := json.Marshal(disclosure)
+			assert.NoError(t, err)
+
+			var decoded rfq.Disclosure
+			assert.NoError(t, json.Unmarshal(data, &decoded))
+			assert.NoError(t, decoded.Verify())
+		})
+	}
+}
+
+func TestDisclosure_Verify_Tampered(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT", rfq.PaymentDetails(map[string]any{"accountNumber": "1234567890"})),
+	)
+	assert.NoError(t, err)
+
+	_, err = r.Disclose(rfq.DisclosePayin)
+	assert.Error(t, err)
+
+	disclosure, err := r.Disclose(rfq.DisclosePayout)
+	assert.NoError(t, err)
+
+	disclosure.Value = map[string]any{"accountNumber": "0987654321"}
+	assert.Error(t, disclosure.Verify())
+
+	disclosure, err = r.Disclose(rfq.DisclosePayout)
+	assert.NoError(t, err)
+
+	disclosure.RFQ.Data.Payin.Amount = "1000"
+	assert.Error(t, disclosure.Verify())
+}
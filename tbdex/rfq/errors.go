@@ -0,0 +1,40 @@
+package rfq
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Errors returned by [RFQ.VerifyOfferingRequirements] when the RFQ does not satisfy the offering's requirements.
+var (
+	ErrOfferingIDMismatch      = errors.New("rfq's offering id does not match offering used to evaluate rfq")
+	ErrPayinMethodNotFound     = errors.New("rfq payin method not found in offering")
+	ErrPayoutMethodNotFound    = errors.New("rfq payout method not found in offering")
+	ErrAmountBelowMin          = errors.New("rfq payin amount is less than offering's minimum amount")
+	ErrAmountAboveMax          = errors.New("rfq payin amount is greater than offering's max amount")
+	ErrUnexpectedPayinDetails  = errors.New("rfq contains unexpected payin details")
+	ErrUnexpectedPayoutDetails = errors.New("rfq contains unexpected payout details")
+	ErrMissingPayinDetails     = errors.New("rfq does not contain expected payin details")
+	ErrMissingPayoutDetails    = errors.New("rfq does not contain expected payout details")
+	ErrInvalidPayinDetails     = errors.New("rfq payin details do not satisfy offering's requirements")
+	ErrInvalidPayoutDetails    = errors.New("rfq payout details do not satisfy offering's requirements")
+	ErrClaimsUnsatisfied       = errors.New("rfq claims do not satisfy offering's requirements")
+)
+
+// AmountError is returned when the RFQ's payin amount is outside of the offering's limits.
+// Err is either [ErrAmountBelowMin] or [ErrAmountAboveMax].
+type AmountError struct {
+	Err    error
+	Limit  decimal.Decimal
+	Amount decimal.Decimal
+}
+
+func (e *AmountError) Error() string {
+	return fmt.Sprintf("%s: amount %s, limit %s", e.Err, e.Amount, e.Limit)
+}
+
+func (e *AmountError) Unwrap() error {
+	return e.Err
+}
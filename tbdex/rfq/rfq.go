@@ -1,6 +1,8 @@
 package rfq
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,11 +13,9 @@ import (
 	"github.com/TBD54566975/tbdex-go/tbdex/crypto"
 	"github.com/TBD54566975/tbdex-go/tbdex/message"
 	_offering "github.com/TBD54566975/tbdex-go/tbdex/offering"
-	"github.com/TBD54566975/tbdex-go/tbdex/quote"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/shopspring/decimal"
 	"github.com/tbd54566975/web5-go/pexv2"
-	"github.com/tbd54566975/web5-go/vc"
 
 	jsonschema "github.com/santhosh-tekuri/jsonschema/v5"
 )
@@ -25,7 +25,8 @@ const Kind = "rfq"
 
 // ValidNext returns the valid message kinds that can follow a RFQ.
 func ValidNext() []string {
-	return []string{quote.Kind, closemsg.Kind, cancel.Kind}
+	// todo hardcoded quote kind here because quote depends on rfq in order to create quotes from rfqs
+	return []string{"quote", closemsg.Kind, cancel.Kind}
 }
 
 // RFQ represents a request for quote message within the exchange.
@@ -46,6 +47,11 @@ func (r RFQ) GetKind() string {
 	return Kind
 }
 
+// GetSignature returns the signature of the message
+func (r RFQ) GetSignature() string {
+	return r.Signature
+}
+
 // GetValidNext returns the valid message kinds that can follow a RFQ.
 func (r RFQ) GetValidNext() []string {
 	return ValidNext()
@@ -80,8 +86,13 @@ func (r *RFQ) UnmarshalJSON(data []byte) error {
 }
 
 // Verify verifies the signature of the RFQ.
-func (r *RFQ) Verify() error {
-	decoded, err := crypto.VerifySignature(r, r.Signature)
+func (r RFQ) Verify() error {
+	return r.VerifyContext(context.Background())
+}
+
+// VerifyContext is like [RFQ.Verify] but uses ctx when resolving the signer's DID.
+func (r RFQ) VerifyContext(ctx context.Context) error {
+	decoded, err := crypto.VerifySignatureContext(ctx, r, r.Signature)
 	if err != nil {
 		return fmt.Errorf("failed to verify RFQ signature: %w", err)
 	}
@@ -101,19 +112,69 @@ func (r *RFQ) Verify() error {
 //   - payout method is present in the offering
 //   - payout details satisfy the offering's required payment details
 //   - claims satisfy the offering's required claims
-func (rfq *RFQ) VerifyOfferingRequirements(offering _offering.Offering) error {
+//
+// The first failed requirement is returned unless [AllErrors] is passed. Failures can be inspected using
+// [errors.Is] with the Err* values in this package and [errors.As] with [*AmountError].
+func (rfq *RFQ) VerifyOfferingRequirements(offering _offering.Offering, opts ...VerifyOption) error {
+	return rfq.VerifyOfferingRequirementsContext(context.Background(), offering, opts...)
+}
+
+// VerifyOfferingRequirementsContext is like [RFQ.VerifyOfferingRequirements] but uses ctx when verifying
+// the credentials presented as claims.
+func (rfq *RFQ) VerifyOfferingRequirementsContext(ctx context.Context, offering _offering.Offering, opts ...VerifyOption) error {
+	o := verifyOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	checks := []func(_offering.Offering) []error{
+		rfq.verifyOfferingID,
+		rfq.verifyPayin,
+		rfq.verifyPayout,
+		func(offering _offering.Offering) []error { return rfq.verifyRequiredClaims(ctx, offering) },
+	}
+
+	var errs []error
+	for _, check := range checks {
+		errs = append(errs, check(offering)...)
+		if len(errs) > 0 && !o.allErrors {
+			return errs[0]
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type verifyOptions struct {
+	allErrors bool
+}
+
+// VerifyOption implements functional options pattern for [RFQ.VerifyOfferingRequirements].
+type VerifyOption func(*verifyOptions)
+
+// AllErrors can be passed to [RFQ.VerifyOfferingRequirements] in order to check every requirement and
+// report all failures using [errors.Join] rather than stopping at the first failure.
+func AllErrors() VerifyOption {
+	return func(o *verifyOptions) {
+		o.allErrors = true
+	}
+}
+
+func (rfq *RFQ) verifyOfferingID(offering _offering.Offering) []error {
 	if rfq.Data.OfferingID != offering.Metadata.ID {
-		return fmt.Errorf("rfq's offering id does not match offering used to evaluate rfq")
+		return []error{ErrOfferingIDMismatch}
 	}
 
-	payinAmount, err := decimal.NewFromString(rfq.Data.Payin.Amount)
-	if err != nil {
-		return fmt.Errorf("failed to parse rfq payin amount: %w", err)
+	return nil
+}
+
+func (rfq *RFQ) verifyPayin(offering _offering.Offering) []error {
+	if offering.Data.Payin == nil {
+		return []error{ErrPayinMethodNotFound}
 	}
 
 	var selectedPayinMethod *_offering.PayinMethod
 	for _, method := range offering.Data.Payin.Methods {
-
 		if method.Kind == rfq.Data.Payin.Kind {
 			selectedPayinMethod = &method
 			break
@@ -121,75 +182,72 @@ func (rfq *RFQ) VerifyOfferingRequirements(offering _offering.Offering) error {
 	}
 
 	if selectedPayinMethod == nil {
-		return errors.New("rfq payin method not found in offering")
-	}
-
-	var min *decimal.Decimal
-	maybeMins := []string{selectedPayinMethod.Min, offering.Data.Payin.Min}
-	for _, maybeMin := range maybeMins {
-		if maybeMin != "" {
-			minAmount, err := decimal.NewFromString(maybeMin)
-			if err != nil {
-				return fmt.Errorf("failed to parse min amount: %w", err)
-			}
-			min = &minAmount
-			break
-		}
-	}
-
-	if min != nil {
-		if payinAmount.LessThan(*min) {
-			return fmt.Errorf("rfq payin amount is less than offering's minimum amount")
-		}
+		return []error{ErrPayinMethodNotFound}
 	}
 
-	var max *decimal.Decimal
-	maybeMaxes := []string{selectedPayinMethod.Max, offering.Data.Payin.Max}
+	var errs []error
 
-	for _, maybeMax := range maybeMaxes {
-		if maybeMax != "" {
-			maxAmount, err := decimal.NewFromString(maybeMax)
-			if err != nil {
-				return fmt.Errorf("failed to parse max amount: %w", err)
-			}
-			max = &maxAmount
-			break
-		}
+	payinAmount, err := decimal.NewFromString(rfq.Data.Payin.Amount)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to parse rfq payin amount: %w", err))
+	} else {
+		errs = append(errs, verifyAmount(payinAmount, selectedPayinMethod, offering.Data.Payin)...)
 	}
 
-	if max != nil {
-		if payinAmount.GreaterThan(*max) {
-			return fmt.Errorf("rfq payin amount is greater than offering's max amount")
-		}
+	var paymentDetails PaymentMethodDetails
+	if rfq.PrivateData != nil {
+		paymentDetails = rfq.PrivateData.Payin.PaymentDetails
 	}
 
-	if selectedPayinMethod.RequiredPaymentDetails == nil && rfq.PrivateData != nil && rfq.PrivateData.Payin.PaymentDetails != nil {
-		return errors.New("rfq contains unexpected payin details")
+	err = verifyPaymentDetails(
+		paymentDetails,
+		selectedPayinMethod.RequiredPaymentDetails,
+		ErrUnexpectedPayinDetails,
+		ErrMissingPayinDetails,
+		ErrInvalidPayinDetails,
+	)
+	if err != nil {
+		errs = append(errs, err)
 	}
 
-	if selectedPayinMethod.RequiredPaymentDetails != nil {
-		if rfq.PrivateData == nil || rfq.PrivateData.Payin.PaymentDetails == nil {
-			return errors.New("rfq does not contain expected payin details")
-		}
+	return errs
+}
+
+// verifyAmount checks the payin amount against the payin method's min and max, falling back to the offering's.
+// See [_offering.PayinDetails.Limits].
+func verifyAmount(amount decimal.Decimal, method *_offering.PayinMethod, payin *_offering.PayinDetails) []error {
+	var errs []error
 
-		payinDetails, err := json.Marshal(rfq.PrivateData.Payin.PaymentDetails)
+	minAmount, maxAmount := payin.Limits(*method)
+
+	if minAmount != "" {
+		min, err := decimal.NewFromString(minAmount)
 		if err != nil {
-			return fmt.Errorf("failed to json marshal rfq payin details: %w", err)
+			errs = append(errs, fmt.Errorf("failed to parse min amount: %w", err))
+		} else if amount.LessThan(min) {
+			errs = append(errs, &AmountError{Err: ErrAmountBelowMin, Limit: min, Amount: amount})
 		}
+	}
 
-		schema, err := jsonschema.CompileString("payin", string(selectedPayinMethod.RequiredPaymentDetails))
+	if maxAmount != "" {
+		max, err := decimal.NewFromString(maxAmount)
 		if err != nil {
-			return fmt.Errorf("failed to compile offering's required payment details")
+			errs = append(errs, fmt.Errorf("failed to parse max amount: %w", err))
+		} else if amount.GreaterThan(max) {
+			errs = append(errs, &AmountError{Err: ErrAmountAboveMax, Limit: max, Amount: amount})
 		}
+	}
 
-		if err := schema.Validate(payinDetails); err != nil {
-			return fmt.Errorf("rfq payin details do not satisfy offering's requirements: %w", err)
-		}
+	return errs
+}
+
+func (rfq *RFQ) verifyPayout(offering _offering.Offering) []error {
+	if offering.Data.Payout == nil {
+		return []error{ErrPayoutMethodNotFound}
 	}
 
 	var selectedPayoutMethod *_offering.PayoutMethod
 	for _, method := range offering.Data.Payout.Methods {
-
 		if method.Kind == rfq.Data.Payout.Kind {
 			selectedPayoutMethod = &method
 			break
@@ -197,45 +255,80 @@ func (rfq *RFQ) VerifyOfferingRequirements(offering _offering.Offering) error {
 	}
 
 	if selectedPayoutMethod == nil {
-		return errors.New("rfq's selected payout method is not present in offering")
+		return []error{ErrPayoutMethodNotFound}
 	}
 
-	if selectedPayoutMethod.RequiredPaymentDetails == nil && rfq.PrivateData != nil && rfq.PrivateData.Payout.PaymentDetails != nil {
-		return errors.New("rfq contains unexpected payout details")
+	var paymentDetails PaymentMethodDetails
+	if rfq.PrivateData != nil {
+		paymentDetails = rfq.PrivateData.Payout.PaymentDetails
 	}
 
-	if selectedPayoutMethod.RequiredPaymentDetails != nil {
-		if rfq.PrivateData == nil || rfq.PrivateData.Payout.PaymentDetails == nil {
-			return errors.New("rfq does not contain expected payout details")
-		}
+	err := verifyPaymentDetails(
+		paymentDetails,
+		selectedPayoutMethod.RequiredPaymentDetails,
+		ErrUnexpectedPayoutDetails,
+		ErrMissingPayoutDetails,
+		ErrInvalidPayoutDetails,
+	)
+	if err != nil {
+		return []error{err}
+	}
 
-		payinDetails, err := json.Marshal(rfq.PrivateData.Payout.PaymentDetails)
-		if err != nil {
-			return fmt.Errorf("failed to json marshal rfq payout details: %w", err)
-		}
+	return nil
+}
 
-		schema, err := jsonschema.CompileString("payout", string(selectedPayoutMethod.RequiredPaymentDetails))
-		if err != nil {
-			return fmt.Errorf("failed to compile offering's required payment details")
+// verifyPaymentDetails checks the payment details against the JSON schema required by the payment method.
+func verifyPaymentDetails(details PaymentMethodDetails, required json.RawMessage, errUnexpected, errMissing, errInvalid error) error {
+	if required == nil {
+		if details != nil {
+			return errUnexpected
 		}
 
-		if err := schema.Validate(payinDetails); err != nil {
-			return fmt.Errorf("rfq payout details do not satisfy offering's requirements: %w", err)
-		}
+		return nil
 	}
 
-	if offering.Data.RequiredClaims != nil {
-		err := rfq.verifyClaims(offering.Data.RequiredClaims)
-		if err != nil {
-			return fmt.Errorf("rfq claims do not satisfy offering's requirements. %w", err)
-		}
+	if details == nil {
+		return errMissing
+	}
+
+	schema, err := jsonschema.CompileString("paymentDetails", string(required))
+	if err != nil {
+		return fmt.Errorf("failed to compile offering's required payment details: %w", err)
+	}
+
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to json marshal rfq payment details: %w", err)
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(detailsJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("failed to json unmarshal rfq payment details: %w", err)
+	}
+
+	if err := schema.Validate(value); err != nil {
+		return fmt.Errorf("%w: %w", errInvalid, err)
+	}
+
+	return nil
+}
+
+func (rfq *RFQ) verifyRequiredClaims(ctx context.Context, offering _offering.Offering) []error {
+	if offering.Data.RequiredClaims == nil {
+		return nil
+	}
+
+	if err := rfq.verifyClaims(ctx, offering.Data.RequiredClaims); err != nil {
+		return []error{fmt.Errorf("%w: %w", ErrClaimsUnsatisfied, err)}
 	}
 
 	return nil
 }
 
 // Scrub verifies the private data and returns an RFQ without private data for storage, as well as private data for separate processing
-// todo: allow passing in custom type for PrivateData.Payin and PrivateData.Payout when PrivateData is genericized. https://github.com/TBD54566975/tbdex-go/issues/50
+// Use [ParseTyped] to decode the payment details in the private data into custom types.
 func (r *RFQ) Scrub() (RFQ, PrivateData, error) {
 
 	err := r.verifyPrivateData()
@@ -260,12 +353,17 @@ func (r *RFQ) Scrub() (RFQ, PrivateData, error) {
 
 // Parse validates, parses input data into an RFQ, and verifies the signature and private data.
 func Parse(data []byte) (RFQ, error) {
+	return ParseContext(context.Background(), data)
+}
+
+// ParseContext is like [Parse] but uses ctx when verifying the signature.
+func ParseContext(ctx context.Context, data []byte) (RFQ, error) {
 	r := RFQ{}
 	if err := json.Unmarshal(data, &r); err != nil {
 		return RFQ{}, fmt.Errorf("failed to unmarshal RFQ: %w", err)
 	}
 
-	if err := r.Verify(); err != nil {
+	if err := r.VerifyContext(ctx); err != nil {
 		return RFQ{}, fmt.Errorf("failed to verify RFQ: %w", err)
 	}
 
@@ -284,16 +382,45 @@ func (r RFQ) Digest() ([]byte, error) {
 	return hashed, nil
 }
 
+// VerifyPrivateData verifies that the claims and payment details in the private data match the hashes in the
+// signed data, and that the private data contains no fields without a hash. Private data is only required if
+// the RFQ contains hashes.
+func (r *RFQ) VerifyPrivateData() error {
+	if r.PrivateData == nil && r.Data.ClaimsHash == "" && r.Data.Payin.PaymentDetailsHash == "" && r.Data.Payout.PaymentDetailsHash == "" {
+		return nil
+	}
+
+	return r.verifyPrivateData()
+}
+
 func (r *RFQ) verifyPrivateData() error {
 	if r.PrivateData == nil {
 		return errors.New("private data is missing")
 	}
 
+	// the spec requires a salt even if every field has its own
+	if r.PrivateData.Salt == "" {
+		return errors.New("verification: private data salt is missing")
+	}
+
+	// private fields without a hash aren't covered by the signature
+	if r.Data.ClaimsHash == "" && len(r.PrivateData.Claims) > 0 {
+		return errors.New("verification: claims are present but claims hash is not set")
+	}
+
+	if r.Data.Payin.PaymentDetailsHash == "" && r.PrivateData.Payin.PaymentDetails != nil {
+		return errors.New("verification: payin details are present but payin details hash is not set")
+	}
+
+	if r.Data.Payout.PaymentDetailsHash == "" && r.PrivateData.Payout.PaymentDetails != nil {
+		return errors.New("verification: payout details are present but payout details hash is not set")
+	}
+
 	if r.Data.ClaimsHash != "" {
 		if len(r.PrivateData.Claims) == 0 {
 			return errors.New("verification: claims hash is set but claims are missing")
 		}
-		payload := []any{r.PrivateData.Salt, r.PrivateData.Claims}
+		payload := []any{r.PrivateData.saltFor(DiscloseClaims), r.PrivateData.Claims}
 		if err := crypto.VerifyDigest(r.Data.ClaimsHash, payload); err != nil {
 			return fmt.Errorf("failed to verify claims: %w", err)
 		}
@@ -304,7 +431,7 @@ func (r *RFQ) verifyPrivateData() error {
 			return errors.New("verification: payin details hash is set but payin details are missing")
 
 		}
-		payload := []any{r.PrivateData.Salt, r.PrivateData.Payin.PaymentDetails}
+		payload := []any{r.PrivateData.saltFor(DisclosePayin), r.PrivateData.Payin.PaymentDetails}
 		if err := crypto.VerifyDigest(r.Data.Payin.PaymentDetailsHash, payload); err != nil {
 			return fmt.Errorf("failed to verify payin: %w", err)
 		}
@@ -314,7 +441,7 @@ func (r *RFQ) verifyPrivateData() error {
 		if r.PrivateData.Payout.PaymentDetails == nil {
 			return errors.New("verification: payout details hash is set but payout details are missing")
 		}
-		payload := []any{r.PrivateData.Salt, r.PrivateData.Payout.PaymentDetails}
+		payload := []any{r.PrivateData.saltFor(DisclosePayout), r.PrivateData.Payout.PaymentDetails}
 		if err := crypto.VerifyDigest(r.Data.Payout.PaymentDetailsHash, payload); err != nil {
 			return fmt.Errorf("failed to verify payout: %w", err)
 		}
@@ -323,7 +450,7 @@ func (r *RFQ) verifyPrivateData() error {
 	return nil
 }
 
-func (r *RFQ) verifyClaims(requiredClaims *pexv2.PresentationDefinition) error {
+func (r *RFQ) verifyClaims(ctx context.Context, requiredClaims *pexv2.PresentationDefinition) error {
 	if requiredClaims == nil {
 		return errors.New("required claims cannot be nil")
 	}
@@ -343,7 +470,7 @@ func (r *RFQ) verifyClaims(requiredClaims *pexv2.PresentationDefinition) error {
 	}
 
 	for _, cred := range credentials {
-		_, err = vc.Verify[vc.Claims](cred)
+		_, err = crypto.VerifyCredentialContext(ctx, cred)
 
 		if err != nil {
 			return fmt.Errorf("failed to verify credential: %w", err)
@@ -362,13 +489,46 @@ type Data struct {
 }
 
 // PrivateData contains data which can be detached from the payload without disrupting integrity.
+// Salt is used to hash every field that doesn't have its own salt in Salts. Salts is not part of the tbdex spec,
+// see [NonStandardPerFieldSalts].
 type PrivateData struct {
 	Salt   string                `json:"salt,omitempty"`
+	Salts  *Salts                `json:"salts,omitempty"`
 	Claims []string              `json:"claims,omitempty"`
 	Payin  PrivatePaymentDetails `json:"payin,omitempty"`
 	Payout PrivatePaymentDetails `json:"payout,omitempty"`
 }
 
+// Salts contains the salt used to hash each field of the [PrivateData] when using [NonStandardPerFieldSalts].
+type Salts struct {
+	Claims string `json:"claims,omitempty"`
+	Payin  string `json:"payin,omitempty"`
+	Payout string `json:"payout,omitempty"`
+}
+
+// saltFor returns the salt used to hash the given field.
+func (p PrivateData) saltFor(field DisclosureField) string {
+	if p.Salts == nil {
+		return p.Salt
+	}
+
+	var salt string
+	switch field {
+	case DiscloseClaims:
+		salt = p.Salts.Claims
+	case DisclosePayin:
+		salt = p.Salts.Payin
+	case DisclosePayout:
+		salt = p.Salts.Payout
+	}
+
+	if salt == "" {
+		return p.Salt
+	}
+
+	return salt
+}
+
 // IsZero checks if struct is empty
 func (p PrivateData) IsZero() bool {
 	v := reflect.ValueOf(p)
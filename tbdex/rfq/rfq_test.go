@@ -2,11 +2,13 @@ package rfq_test
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/TBD54566975/tbdex-go/tbdex/offering"
 	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/alecthomas/assert/v2"
 	"github.com/tbd54566975/web5-go/dids/did"
 	"github.com/tbd54566975/web5-go/dids/didjwk"
@@ -39,6 +41,34 @@ func TestCreate(t *testing.T) {
 	assert.Zero(t, rfq.Data.ClaimsHash)
 }
 
+func TestCreate_Protocol(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	offeringID, _ := typeid.WithPrefix(offering.Kind)
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		offeringID.String(),
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+		rfq.Protocol("1.0"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0", r.Metadata.Protocol)
+	assert.NoError(t, r.Verify())
+
+	_, err = rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		offeringID.String(),
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+		rfq.Protocol("1.1"),
+	)
+	assert.Error(t, err)
+}
+
 func TestCreate_WithPrivate(t *testing.T) {
 	pfiDID, _ := didjwk.Create()
 	walletDID, _ := didjwk.Create()
@@ -119,6 +149,34 @@ func TestUnmarshal_Invalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestParse_ValidationError(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+	)
+	assert.NoError(t, err)
+
+	var generic map[string]any
+	data, _ := json.Marshal(r)
+	assert.NoError(t, json.Unmarshal(data, &generic))
+	delete(generic["data"].(map[string]any)["payin"].(map[string]any), "kind")
+	data, _ = json.Marshal(generic)
+
+	_, err = rfq.Parse(data)
+
+	var verr *validator.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, 1, len(verr.Details))
+	assert.Equal(t, "/data/payin", verr.Details[0].Pointer)
+	assert.Equal(t, "required", verr.Details[0].Keyword)
+}
+
 func TestScrub_FailsNoPrivateData(t *testing.T) {
 	pfiDID, _ := didjwk.Create()
 	walletDID, _ := didjwk.Create()
@@ -338,6 +396,36 @@ func TestScrub_FailsMissingDataForPayoutHash(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVerifyPrivateData(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	offeringID, _ := typeid.WithPrefix(offering.Kind)
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		offeringID.String(),
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT", rfq.PaymentDetails(map[string]any{"clabe": "123456789012345678"})),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, r.VerifyPrivateData())
+
+	r.PrivateData.Payout.PaymentDetails = map[string]any{"clabe": "876543210987654321"}
+	assert.Error(t, r.VerifyPrivateData())
+
+	r.PrivateData = nil
+	assert.Error(t, r.VerifyPrivateData())
+
+	// private data is not required without hashes
+	r.Data.Payout.PaymentDetailsHash = ""
+	assert.NoError(t, r.VerifyPrivateData())
+
+	// but private fields without a hash are rejected
+	r.PrivateData = &rfq.PrivateData{Claims: []string{"eyJhbGciOiJFZERTQSJ9..."}}
+	assert.Error(t, r.VerifyPrivateData())
+}
+
 func TestVerify_FailsBadSignature(t *testing.T) {
 	pfiDID, _ := didjwk.Create()
 	walletDID, _ := didjwk.Create()
@@ -691,7 +779,62 @@ func TestVerifyOfferingRequirements(t *testing.T) {
 
 	})
 
-	t.Run("payout_details_invalid", func(t *testing.T) {})
+	t.Run("payout_details_invalid", func(t *testing.T) {
+		pfiDID, _ := didjwk.Create()
+		walletDID, _ := didjwk.Create()
+
+		offering, err := offering.Create(
+			offering.NewPayin(
+				"USD",
+				[]offering.PayinMethod{offering.NewPayinMethod("SQUAREPAY")},
+			),
+			offering.NewPayout(
+				"MXN",
+				[]offering.PayoutMethod{
+					offering.NewPayoutMethod(
+						"SPEI",
+						20*time.Minute,
+						offering.RequiredDetails(`{
+							"$schema": "http://json-schema.org/draft-07/schema#",
+							"additionalProperties": false,
+							"properties": {
+								"clabe": {
+									"type": "string"
+								}
+							},
+							"required": ["clabe"]
+						}`),
+					),
+				},
+			),
+			"16.0",
+			offering.NewCancellationDetails(false),
+			offering.From(pfiDID),
+		)
+		assert.NoError(t, err)
+
+		r, _ := rfq.Create(
+			walletDID,
+			pfiDID.URI,
+			offering.Metadata.ID,
+			rfq.Payin("100", "SQUAREPAY"),
+			rfq.Payout("SPEI", rfq.PaymentDetails(map[string]any{"accountNumber": "1234567890123456"})),
+		)
+
+		err = r.VerifyOfferingRequirements(offering)
+		assert.True(t, errors.Is(err, rfq.ErrInvalidPayoutDetails))
+
+		r, _ = rfq.Create(
+			walletDID,
+			pfiDID.URI,
+			offering.Metadata.ID,
+			rfq.Payin("100", "SQUAREPAY"),
+			rfq.Payout("SPEI", rfq.PaymentDetails(map[string]any{"clabe": "032180000118359719"})),
+		)
+
+		err = r.VerifyOfferingRequirements(offering)
+		assert.NoError(t, err)
+	})
 
 	t.Run("claims_missing", func(t *testing.T) {
 		pfiDID, _ := didjwk.Create()
@@ -859,3 +1002,48 @@ func TestVerifyOfferingRequirements(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestVerifyOfferingRequirements_Errors(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, err := offering.Create(
+		offering.NewPayin(
+			"USD",
+			[]offering.PayinMethod{offering.NewPayinMethod("SQUAREPAY", offering.MethodMin("10"))},
+			offering.Min("5"),
+			offering.Max("100"),
+		),
+		offering.NewPayout(
+			"USDC",
+			[]offering.PayoutMethod{offering.NewPayoutMethod("STORED_BALANCE", 20*time.Minute)},
+		),
+		"1.0",
+		offering.NewCancellationDetails(false),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		o.Metadata.ID,
+		rfq.Payin("7", "SQUAREPAY"),
+		rfq.Payout("BANK_ACCOUNT"),
+	)
+	assert.NoError(t, err)
+
+	err = r.VerifyOfferingRequirements(o)
+	assert.True(t, errors.Is(err, rfq.ErrAmountBelowMin))
+	assert.False(t, errors.Is(err, rfq.ErrPayoutMethodNotFound))
+
+	var amountErr *rfq.AmountError
+	assert.True(t, errors.As(err, &amountErr))
+	assert.Equal(t, "10", amountErr.Limit.String())
+	assert.Equal(t, "7", amountErr.Amount.String())
+
+	err = r.VerifyOfferingRequirements(o, rfq.AllErrors())
+	assert.True(t, errors.Is(err, rfq.ErrAmountBelowMin))
+	assert.True(t, errors.Is(err, rfq.ErrPayoutMethodNotFound))
+	assert.False(t, errors.Is(err, rfq.ErrOfferingIDMismatch))
+}
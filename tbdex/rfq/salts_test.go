@@ -0,0 +1,86 @@
+package rfq_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/validator"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func TestPrivateDataVectors(t *testing.T) {
+	tests := []struct {
+		file          string
+		perFieldSalts bool
+	}{
+		{file: "testdata/rfq-single-salt.json"},
+		{file: "testdata/rfq-per-field-salts.json", perFieldSalts: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(tt.file)
+			assert.NoError(t, err)
+
+			r, err := rfq.Parse(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.perFieldSalts, r.PrivateData.Salts != nil)
+
+			for _, field := range []rfq.DisclosureField{rfq.DiscloseClaims, rfq.DisclosePayin, rfq.DisclosePayout} {
+				disclosure, err := r.Disclose(field)
+				assert.NoError(t, err)
+				assert.NoError(t, disclosure.Verify())
+			}
+
+			expected := *r.PrivateData
+
+			scrubbed, privateData, err := r.Scrub()
+			assert.NoError(t, err)
+			assert.Zero(t, scrubbed.PrivateData)
+			assert.Equal(t, expected, privateData)
+		})
+	}
+}
+
+func TestCreate_NonStandardPerFieldSalts(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT", rfq.PaymentDetails(map[string]any{"accountNumber": "1234567890"})),
+		rfq.Claims([]string{"claim"}),
+		rfq.NonStandardPerFieldSalts(),
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, validator.DefaultProtocol, r.Metadata.Protocol)
+	assert.NotZero(t, r.PrivateData.Salt)
+	assert.NotZero(t, r.PrivateData.Salts.Claims)
+	assert.NotZero(t, r.PrivateData.Salts.Payout)
+	assert.Zero(t, r.PrivateData.Salts.Payin)
+	assert.NotEqual(t, r.PrivateData.Salts.Claims, r.PrivateData.Salts.Payout)
+	assert.NotEqual(t, r.PrivateData.Salt, r.PrivateData.Salts.Payout)
+
+	_, _, err = r.Scrub()
+	assert.NoError(t, err)
+}
+
+func TestScrub_MissingSalt(t *testing.T) {
+	data, err := os.ReadFile("testdata/rfq-per-field-salts.json")
+	assert.NoError(t, err)
+
+	r, err := rfq.Parse(data)
+	assert.NoError(t, err)
+
+	// every field has its own salt, but the spec still requires the shared salt
+	r.PrivateData.Salt = ""
+
+	_, _, err = r.Scrub()
+	assert.Error(t, err)
+}
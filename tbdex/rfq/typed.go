@@ -0,0 +1,158 @@
+package rfq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tbd54566975/web5-go/dids/did"
+)
+
+// TypedRFQ is an [RFQ] whose private payment details have been decoded into caller-provided types.
+// PayinDetails and PayoutDetails are nil when the RFQ does not contain the corresponding payment details.
+// TypedRFQ marshals to the same JSON as the embedded RFQ.
+type TypedRFQ[PayinT, PayoutT any] struct {
+	RFQ
+	PayinDetails  *PayinT  `json:"-"`
+	PayoutDetails *PayoutT `json:"-"`
+}
+
+// TypedPayinMethod is used to create the payin method for an RFQ with [CreateTyped].
+type TypedPayinMethod[T any] struct {
+	Amount         string
+	Kind           string
+	PaymentDetails *T
+}
+
+// TypedPayoutMethod is used to create the payout method for an RFQ with [CreateTyped].
+type TypedPayoutMethod[T any] struct {
+	Kind           string
+	PaymentDetails *T
+}
+
+// CreateTyped is like [Create] but accepts payment details of caller-provided types. The payment details
+// must marshal to JSON objects. The resulting hashes are identical to those computed by [Create] for
+// the equivalent untyped payment details.
+func CreateTyped[PayinT, PayoutT any](
+	fromDID did.BearerDID,
+	to, offeringID string,
+	payin TypedPayinMethod[PayinT],
+	payout TypedPayoutMethod[PayoutT],
+	opts ...CreateOption,
+) (TypedRFQ[PayinT, PayoutT], error) {
+	payinDetails, err := toPaymentMethodDetails(payin.PaymentDetails)
+	if err != nil {
+		return TypedRFQ[PayinT, PayoutT]{}, fmt.Errorf("invalid payin details: %w", err)
+	}
+
+	payoutDetails, err := toPaymentMethodDetails(payout.PaymentDetails)
+	if err != nil {
+		return TypedRFQ[PayinT, PayoutT]{}, fmt.Errorf("invalid payout details: %w", err)
+	}
+
+	r, err := Create(
+		fromDID,
+		to,
+		offeringID,
+		PayinMethod{Amount: payin.Amount, Kind: payin.Kind, PaymentDetails: payinDetails},
+		PayoutMethod{Kind: payout.Kind, PaymentDetails: payoutDetails},
+		opts...,
+	)
+	if err != nil {
+		return TypedRFQ[PayinT, PayoutT]{}, err
+	}
+
+	return TypedRFQ[PayinT, PayoutT]{
+		RFQ:           r,
+		PayinDetails:  payin.PaymentDetails,
+		PayoutDetails: payout.PaymentDetails,
+	}, nil
+}
+
+// ParseTyped is like [Parse] but additionally verifies the private data against the hashes in the RFQ and
+// decodes the payment details into the provided types. The payment details are decoded from the input
+// directly so that no precision is lost.
+func ParseTyped[PayinT, PayoutT any](data []byte) (TypedRFQ[PayinT, PayoutT], error) {
+	return ParseTypedContext[PayinT, PayoutT](context.Background(), data)
+}
+
+// ParseTypedContext is like [ParseTyped] but uses ctx when verifying the signature.
+func ParseTypedContext[PayinT, PayoutT any](ctx context.Context, data []byte) (TypedRFQ[PayinT, PayoutT], error) {
+	r, err := ParseContext(ctx, data)
+	if err != nil {
+		return TypedRFQ[PayinT, PayoutT]{}, err
+	}
+
+	typed := TypedRFQ[PayinT, PayoutT]{RFQ: r}
+	if r.PrivateData == nil {
+		return typed, nil
+	}
+
+	if err := r.verifyPrivateData(); err != nil {
+		return TypedRFQ[PayinT, PayoutT]{}, fmt.Errorf("failed to verify private data: %w", err)
+	}
+
+	var raw struct {
+		PrivateData struct {
+			Payin struct {
+				PaymentDetails json.RawMessage `json:"paymentDetails"`
+			} `json:"payin"`
+			Payout struct {
+				PaymentDetails json.RawMessage `json:"paymentDetails"`
+			} `json:"payout"`
+		} `json:"privateData"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return TypedRFQ[PayinT, PayoutT]{}, fmt.Errorf("failed to JSON unmarshal private data: %w", err)
+	}
+
+	typed.PayinDetails, err = fromRawPaymentDetails[PayinT](raw.PrivateData.Payin.PaymentDetails)
+	if err != nil {
+		return TypedRFQ[PayinT, PayoutT]{}, fmt.Errorf("failed to decode payin details: %w", err)
+	}
+
+	typed.PayoutDetails, err = fromRawPaymentDetails[PayoutT](raw.PrivateData.Payout.PaymentDetails)
+	if err != nil {
+		return TypedRFQ[PayinT, PayoutT]{}, fmt.Errorf("failed to decode payout details: %w", err)
+	}
+
+	return typed, nil
+}
+
+// toPaymentMethodDetails converts typed payment details into [PaymentMethodDetails].
+func toPaymentMethodDetails[T any](details *T) (PaymentMethodDetails, error) {
+	if details == nil {
+		return nil, nil
+	}
+
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to JSON marshal payment details: %w", err)
+	}
+
+	// numbers are kept as json.Number so that they are marshaled into the private data without losing precision
+	var pmd PaymentMethodDetails
+	decoder := json.NewDecoder(bytes.NewReader(detailsJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&pmd); err != nil {
+		return nil, fmt.Errorf("payment details must be a JSON object: %w", err)
+	}
+
+	return pmd, nil
+}
+
+// fromRawPaymentDetails decodes raw payment details into T. Absent payment details result in nil.
+func fromRawPaymentDetails[T any](raw json.RawMessage) (*T, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	details := new(T)
+	if err := json.Unmarshal(raw, details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
@@ -0,0 +1,117 @@
+package rfq_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+type bankAccount struct {
+	AccountNumber string `json:"accountNumber"`
+	RoutingNumber string `json:"routingNumber,omitempty"`
+}
+
+type walletAddress struct {
+	Address string `json:"address"`
+	Memo    int64  `json:"memo"`
+}
+
+func TestCreateTyped(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	payin := &bankAccount{AccountNumber: "1234567890123456"}
+	payout := &walletAddress{Address: "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", Memo: 9007199254740993}
+
+	created, err := rfq.CreateTyped(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.TypedPayinMethod[bankAccount]{Amount: "100", Kind: "BANK_ACCOUNT", PaymentDetails: payin},
+		rfq.TypedPayoutMethod[walletAddress]{Kind: "BTC_ADDRESS", PaymentDetails: payout},
+	)
+	assert.NoError(t, err)
+	assert.NotZero(t, created.Data.Payin.PaymentDetailsHash)
+	assert.NotZero(t, created.Data.Payout.PaymentDetailsHash)
+
+	typedJSON, err := json.Marshal(created)
+	assert.NoError(t, err)
+
+	untypedJSON, err := json.Marshal(created.RFQ)
+	assert.NoError(t, err)
+	assert.Equal(t, string(untypedJSON), string(typedJSON))
+
+	parsed, err := rfq.ParseTyped[bankAccount, walletAddress](typedJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, payin, parsed.PayinDetails)
+	assert.Equal(t, payout, parsed.PayoutDetails)
+
+	// hashes computed from typed details verify against the untyped representation
+	untyped, err := rfq.Parse(typedJSON)
+	assert.NoError(t, err)
+
+	_, _, err = untyped.Scrub()
+	assert.NoError(t, err)
+}
+
+func TestParseTyped_NoPaymentDetails(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	created, err := rfq.CreateTyped(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.TypedPayinMethod[bankAccount]{Amount: "100", Kind: "STORED_BALANCE"},
+		rfq.TypedPayoutMethod[walletAddress]{Kind: "BTC_ADDRESS", PaymentDetails: &walletAddress{Address: "bc1q"}},
+	)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(created)
+	assert.NoError(t, err)
+
+	parsed, err := rfq.ParseTyped[bankAccount, walletAddress](data)
+	assert.NoError(t, err)
+	assert.Zero(t, parsed.PayinDetails)
+	assert.Equal(t, "bc1q", parsed.PayoutDetails.Address)
+}
+
+func TestParseTyped_TamperedPrivateData(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	created, err := rfq.CreateTyped(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.TypedPayinMethod[bankAccount]{Amount: "100", Kind: "BANK_ACCOUNT", PaymentDetails: &bankAccount{AccountNumber: "1"}},
+		rfq.TypedPayoutMethod[walletAddress]{Kind: "STORED_BALANCE"},
+	)
+	assert.NoError(t, err)
+
+	created.PrivateData.Payin.PaymentDetails["accountNumber"] = "2"
+
+	data, err := json.Marshal(created)
+	assert.NoError(t, err)
+
+	_, err = rfq.ParseTyped[bankAccount, walletAddress](data)
+	assert.Error(t, err)
+}
+
+func TestCreateTyped_NotAnObject(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	details := "not an object"
+	_, err := rfq.CreateTyped(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.TypedPayinMethod[string]{Amount: "100", Kind: "BANK_ACCOUNT", PaymentDetails: &details},
+		rfq.TypedPayoutMethod[walletAddress]{Kind: "STORED_BALANCE"},
+	)
+	assert.Error(t, err)
+}
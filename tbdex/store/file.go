@@ -0,0 +1,415 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+)
+
+const (
+	// logExtension is the file extension used for exchange logs.
+	logExtension = ".log"
+	// recordHeaderSize is the size of the header preceding each record: the payload length, the checksum of the
+	// length and the checksum of the payload. The length has its own checksum so that a corrupt length is
+	// detected before it is used to find the end of the record.
+	recordHeaderSize = 12
+	// maxRecordSize bounds the payload length read from a record header so that a corrupt length
+	// can't cause an unbounded allocation.
+	maxRecordSize = 16 << 20
+)
+
+var (
+	crcTable        = crc32.MakeTable(crc32.Castagnoli)
+	validExchangeID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// ErrCorruptLog is returned when an exchange log contains a record that cannot be read.
+var ErrCorruptLog = errors.New("corrupt exchange log")
+
+// errTruncatedRecord is returned for a record that ends past the end of the log, which is the result of an
+// interrupted append.
+var errTruncatedRecord = fmt.Errorf("truncated record: %w", ErrCorruptLog)
+
+// FileExchanges is an [ExchangesStore] that persists each exchange as an append-only log file within a directory.
+// Every record holds the raw JSON of a signed message exactly as it was received, so signatures remain
+// verifiable after a reload. Appends are fsynced before they are acknowledged.
+//
+// All logs are read and every message is parsed and verified when the store is opened.
+// FileExchanges is safe for concurrent use within a single process.
+type FileExchanges struct {
+	dir string
+
+	mu          sync.RWMutex
+	exchanges   map[string]*fileExchange
+	exchangeIDs []string
+}
+
+type fileExchange struct {
+	exchange *tbdex.Exchange
+	raw      []json.RawMessage
+}
+
+var _ ExchangesStore = (*FileExchanges)(nil)
+
+type fileOptions struct {
+	truncateCorruptTail bool
+}
+
+// FileOption implements functional options pattern for [OpenFileExchanges].
+type FileOption func(*fileOptions)
+
+// TruncateCorruptTail can be passed to [OpenFileExchanges] to discard an incomplete final record, i.e. one whose
+// header or payload extends past the end of the log, which is the result of a crash mid-append, instead of failing
+// to open the store. Records whose checksums don't match are always reported as an error.
+func TruncateCorruptTail() FileOption {
+	return func(o *fileOptions) {
+		o.truncateCorruptTail = true
+	}
+}
+
+// OpenFileExchanges opens the exchange logs stored in dir, creating dir if it does not exist.
+func OpenFileExchanges(dir string, opts ...FileOption) (*FileExchanges, error) {
+	o := fileOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create exchanges directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchanges directory: %w", err)
+	}
+
+	s := &FileExchanges{dir: dir, exchanges: make(map[string]*fileExchange)}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), logExtension) {
+			continue
+		}
+
+		exchangeID := strings.TrimSuffix(entry.Name(), logExtension)
+
+		fe, err := s.load(exchangeID, o.truncateCorruptTail)
+		if err != nil {
+			return nil, err
+		}
+
+		if fe == nil {
+			continue
+		}
+
+		s.exchanges[exchangeID] = fe
+		s.exchangeIDs = append(s.exchangeIDs, exchangeID)
+	}
+
+	sort.Slice(s.exchangeIDs, func(i, j int) bool {
+		a := s.exchanges[s.exchangeIDs[i]].exchange.RFQ().Metadata
+		b := s.exchanges[s.exchangeIDs[j]].exchange.RFQ().Metadata
+		if !a.CreatedAt.Time().Equal(b.CreatedAt.Time()) {
+			return a.CreatedAt.Time().Before(b.CreatedAt.Time())
+		}
+
+		return a.ID < b.ID
+	})
+
+	return s, nil
+}
+
+// AddMessage implements [ExchangesStore]. The message is stored as re-marshaled JSON unless it is a [tbdex.RawMessage],
+// which is stored verbatim. Use [tbdex.RawMessage] or [FileExchanges.AddRawMessage] to store messages exactly as
+// they were received.
+func (s *FileExchanges) AddMessage(ctx context.Context, msg tbdex.Message) error {
+	if rawMsg, ok := msg.(tbdex.RawMessage); ok {
+		if raw := rawMsg.Raw(); raw != nil {
+			return s.add(msg, raw)
+		}
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to JSON marshal %s: %w", msg.GetKind(), err)
+	}
+
+	return s.add(msg, raw)
+}
+
+// AddRawMessage parses and verifies the raw message and appends it to its exchange's log unmodified.
+func (s *FileExchanges) AddRawMessage(ctx context.Context, raw []byte) error {
+	msg, err := tbdex.ParseRawMessageContext(ctx, raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse message: %w", err)
+	}
+
+	return s.add(msg, raw)
+}
+
+// GetExchange implements [ExchangesStore]. Every message is a [tbdex.RawMessage] holding the stored JSON.
+func (s *FileExchanges) GetExchange(ctx context.Context, exchangeID string) ([]tbdex.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fe, ok := s.exchanges[exchangeID]
+	if !ok {
+		return nil, fmt.Errorf("exchange %s: %w", exchangeID, ErrNotFound)
+	}
+
+	return fe.exchange.Messages(), nil
+}
+
+// GetRawExchange returns the raw JSON of each message in the exchange exactly as it was stored.
+func (s *FileExchanges) GetRawExchange(ctx context.Context, exchangeID string) ([]json.RawMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fe, ok := s.exchanges[exchangeID]
+	if !ok {
+		return nil, fmt.Errorf("exchange %s: %w", exchangeID, ErrNotFound)
+	}
+
+	raw := make([]json.RawMessage, len(fe.raw))
+	for i, r := range fe.raw {
+		raw[i] = append(json.RawMessage(nil), r...)
+	}
+
+	return raw, nil
+}
+
+// GetExchangeIDs implements [ExchangesStore].
+func (s *FileExchanges) GetExchangeIDs(ctx context.Context, filter ExchangesFilter, page Page) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []string
+	for _, id := range s.exchangeIDs {
+		if filter.matches(id, s.exchanges[id].exchange) {
+			ids = append(ids, id)
+		}
+	}
+
+	return paginate(ids, page), nil
+}
+
+// add validates msg against its exchange, appends raw to the exchange's log and then updates the in-memory state.
+func (s *FileExchanges) add(msg tbdex.Message, raw []byte) error {
+	msg = tbdex.NewRawMessage(msg, raw)
+
+	exchangeID := msg.GetMetadata().ExchangeID
+	if !validExchangeID.MatchString(exchangeID) {
+		return fmt.Errorf("invalid exchange id: %q", exchangeID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fe, ok := s.exchanges[exchangeID]
+
+	var existing []tbdex.Message
+	if ok {
+		existing = fe.exchange.Messages()
+	}
+
+	candidate, err := tbdex.NewExchange(append(existing, msg)...)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to exchange %s: %w: %w", msg.GetKind(), exchangeID, ErrConflict, err)
+	}
+
+	if err := s.appendRecord(exchangeID, raw, !ok); err != nil {
+		return err
+	}
+
+	if !ok {
+		fe = &fileExchange{}
+		s.exchanges[exchangeID] = fe
+		s.exchangeIDs = append(s.exchangeIDs, exchangeID)
+	}
+
+	fe.exchange = candidate
+	fe.raw = append(fe.raw, append(json.RawMessage(nil), raw...))
+
+	return nil
+}
+
+// appendRecord writes a single record to the end of the exchange's log and fsyncs it. If the write fails,
+// the log is truncated back to its previous size so that later appends don't follow a partial record.
+func (s *FileExchanges) appendRecord(exchangeID string, payload []byte, create bool) error {
+	path := s.logPath(exchangeID)
+
+	flags := os.O_WRONLY | os.O_APPEND
+	if create {
+		flags |= os.O_CREATE
+	}
+
+	f, err := os.OpenFile(path, flags, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open exchange log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat exchange log: %w", err)
+	}
+
+	record := make([]byte, recordHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(record[0:4], crcTable))
+	binary.BigEndian.PutUint32(record[8:12], crc32.Checksum(payload, crcTable))
+	copy(record[recordHeaderSize:], payload)
+
+	if _, err := f.Write(record); err != nil {
+		_ = f.Truncate(info.Size())
+		return fmt.Errorf("failed to append to exchange log: %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Truncate(info.Size())
+		return fmt.Errorf("failed to sync exchange log: %w", err)
+	}
+
+	if create {
+		if err := syncDir(s.dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// load reads and replays the exchange's log. nil is returned if the log contains no records.
+func (s *FileExchanges) load(exchangeID string, truncateCorruptTail bool) (*fileExchange, error) {
+	path := s.logPath(exchangeID)
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open exchange log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat exchange log: %w", err)
+	}
+
+	fe := &fileExchange{exchange: &tbdex.Exchange{}}
+	reader := bufio.NewReader(f)
+
+	var offset int64
+	for offset < info.Size() {
+		payload, err := readRecord(reader, info.Size()-offset)
+		if err != nil {
+			if !errors.Is(err, ErrCorruptLog) {
+				return nil, fmt.Errorf("failed to read exchange log %s: %w", path, err)
+			}
+
+			// only an incomplete final record can be the result of a crash mid-append
+			if !truncateCorruptTail || !errors.Is(err, errTruncatedRecord) {
+				return nil, fmt.Errorf("%s at offset %d: %w", path, offset, err)
+			}
+
+			if err := f.Truncate(offset); err != nil {
+				return nil, fmt.Errorf("failed to truncate exchange log: %w", err)
+			}
+
+			if err := f.Sync(); err != nil {
+				return nil, fmt.Errorf("failed to sync exchange log: %w", err)
+			}
+
+			break
+		}
+
+		msg, err := tbdex.ParseRawMessage(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse message in %s at offset %d: %w", path, offset, err)
+		}
+
+		if msg.GetMetadata().ExchangeID != exchangeID {
+			return nil, fmt.Errorf("%s contains message for exchange %s", path, msg.GetMetadata().ExchangeID)
+		}
+
+		if err := fe.exchange.Add(msg); err != nil {
+			return nil, fmt.Errorf("invalid message in %s at offset %d: %w", path, offset, err)
+		}
+
+		fe.raw = append(fe.raw, payload)
+		offset += int64(recordHeaderSize + len(payload))
+	}
+
+	if len(fe.raw) == 0 {
+		return nil, nil
+	}
+
+	return fe, nil
+}
+
+// readRecord reads a single record given the number of bytes left in the log. [ErrCorruptLog] is returned if a
+// checksum does not match, wrapped by errTruncatedRecord if the header or the payload it declares extend past the
+// end of the log.
+func readRecord(r io.Reader, available int64) ([]byte, error) {
+	if available < recordHeaderSize {
+		return nil, fmt.Errorf("incomplete record header: %w", errTruncatedRecord)
+	}
+
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	if crc32.Checksum(header[0:4], crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, fmt.Errorf("record length checksum mismatch: %w", ErrCorruptLog)
+	}
+
+	if length > maxRecordSize {
+		return nil, fmt.Errorf("record length %d exceeds maximum: %w", length, ErrCorruptLog)
+	}
+
+	if available < recordHeaderSize+int64(length) {
+		return nil, fmt.Errorf("record of %d bytes with %d bytes left: %w", length, available-recordHeaderSize, errTruncatedRecord)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+
+	if crc32.Checksum(payload, crcTable) != binary.BigEndian.Uint32(header[8:12]) {
+		return nil, fmt.Errorf("record checksum mismatch: %w", ErrCorruptLog)
+	}
+
+	return payload, nil
+}
+
+func (s *FileExchanges) logPath(exchangeID string) string {
+	return filepath.Join(s.dir, exchangeID+logExtension)
+}
+
+// syncDir fsyncs a directory so that newly created files within it are durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open exchanges directory: %w", err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync exchanges directory: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/quote"
+	"github.com/TBD54566975/tbdex-go/tbdex/store"
+	"github.com/alecthomas/assert/v2"
+	"github.com/shopspring/decimal"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func TestFileExchanges(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	s, err := store.OpenFileExchanges(dir)
+	assert.NoError(t, err)
+
+	r := createRFQ(t, walletDID, pfiDID)
+	exchangeID := r.Metadata.ExchangeID
+
+	rawRFQ, err := json.Marshal(r)
+	assert.NoError(t, err)
+
+	// reorder and indent the rfq so that it differs byte-wise from a re-marshaled rfq
+	var generic map[string]any
+	assert.NoError(t, json.Unmarshal(rawRFQ, &generic))
+	rawRFQ, err = json.MarshalIndent(generic, "", "  ")
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.AddRawMessage(ctx, rawRFQ))
+
+	q, err := quote.Create(
+		pfiDID,
+		walletDID.URI,
+		exchangeID,
+		time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"16.665",
+		quote.NewQuoteDetails("USD", decimal.RequireFromString("100")),
+		quote.NewQuoteDetails("MXN", decimal.RequireFromString("1666.5")),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, s.AddMessage(ctx, q))
+
+	o, err := order.Create(pfiDID, walletDID.URI, exchangeID)
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(s.AddMessage(ctx, o), store.ErrConflict))
+
+	reopened, err := store.OpenFileExchanges(dir)
+	assert.NoError(t, err)
+
+	messages, err := reopened.GetExchange(ctx, exchangeID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(messages))
+
+	raw, err := reopened.GetRawExchange(ctx, exchangeID)
+	assert.NoError(t, err)
+	assert.Equal(t, string(rawRFQ), string(raw[0]))
+
+	// stored messages marshal to the JSON they were received as. encoding/json compacts the output
+	rfqJSON, err := json.Marshal(messages[0])
+	assert.NoError(t, err)
+
+	var compacted bytes.Buffer
+	assert.NoError(t, json.Compact(&compacted, rawRFQ))
+	assert.Equal(t, compacted.String(), string(rfqJSON))
+
+	ids, err := reopened.GetExchangeIDs(ctx, store.ExchangesFilter{From: walletDID.URI}, store.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{exchangeID}, ids)
+
+	c, err := closemsg.Create(pfiDID, walletDID.URI, exchangeID)
+	assert.NoError(t, err)
+	assert.NoError(t, reopened.AddMessage(ctx, c))
+
+	messages, err = reopened.GetExchange(ctx, exchangeID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(messages))
+}
+
+func TestFileExchanges_AddRawMessage(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	s, err := store.OpenFileExchanges(t.TempDir())
+	assert.NoError(t, err)
+
+	rawRFQ, err := json.MarshalIndent(createRFQ(t, walletDID, pfiDID), "", "  ")
+	assert.NoError(t, err)
+
+	msg, err := tbdex.ParseRawMessage(rawRFQ)
+	assert.NoError(t, err)
+	assert.NoError(t, s.AddMessage(ctx, msg))
+
+	raw, err := s.GetRawExchange(ctx, msg.GetMetadata().ExchangeID)
+	assert.NoError(t, err)
+	assert.Equal(t, string(rawRFQ), string(raw[0]))
+}
+
+func TestFileExchanges_TruncatedTail(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	s, err := store.OpenFileExchanges(dir)
+	assert.NoError(t, err)
+
+	r := createRFQ(t, walletDID, pfiDID)
+	assert.NoError(t, s.AddMessage(ctx, r))
+
+	c, err := closemsg.Create(pfiDID, walletDID.URI, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+	assert.NoError(t, s.AddMessage(ctx, c))
+
+	path := filepath.Join(dir, r.Metadata.ExchangeID+".log")
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+
+	// simulate a crash mid-append
+	assert.NoError(t, os.Truncate(path, info.Size()-10))
+
+	_, err = store.OpenFileExchanges(dir)
+	assert.True(t, errors.Is(err, store.ErrCorruptLog))
+
+	repaired, err := store.OpenFileExchanges(dir, store.TruncateCorruptTail())
+	assert.NoError(t, err)
+
+	messages, err := repaired.GetExchange(ctx, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(messages))
+
+	assert.NoError(t, repaired.AddMessage(ctx, c))
+
+	reopened, err := store.OpenFileExchanges(dir)
+	assert.NoError(t, err)
+
+	messages, err = reopened.GetExchange(ctx, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(messages))
+}
+
+func TestFileExchanges_CorruptRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	s, err := store.OpenFileExchanges(dir)
+	assert.NoError(t, err)
+
+	r := createRFQ(t, walletDID, pfiDID)
+	assert.NoError(t, s.AddMessage(ctx, r))
+
+	c, err := closemsg.Create(pfiDID, walletDID.URI, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+	assert.NoError(t, s.AddMessage(ctx, c))
+
+	path := filepath.Join(dir, r.Metadata.ExchangeID+".log")
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	// flip a byte within the first record's payload
+	data[20] ^= 0xff
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+
+	_, err = store.OpenFileExchanges(dir, store.TruncateCorruptTail())
+	assert.True(t, errors.Is(err, store.ErrCorruptLog))
+}
+
+func TestFileExchanges_CorruptLength(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	s, err := store.OpenFileExchanges(dir)
+	assert.NoError(t, err)
+
+	r := createRFQ(t, walletDID, pfiDID)
+	assert.NoError(t, s.AddMessage(ctx, r))
+
+	c, err := closemsg.Create(pfiDID, walletDID.URI, r.Metadata.ExchangeID)
+	assert.NoError(t, err)
+	assert.NoError(t, s.AddMessage(ctx, c))
+
+	path := filepath.Join(dir, r.Metadata.ExchangeID+".log")
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	// the first record's length now points past the end of the log
+	binary.BigEndian.PutUint32(data[0:4], uint32(len(data)))
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+
+	// the corrupt length isn't mistaken for an interrupted append, which would discard the valid record after it
+	_, err = store.OpenFileExchanges(dir, store.TruncateCorruptTail())
+	assert.True(t, errors.Is(err, store.ErrCorruptLog))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), info.Size())
+}
@@ -0,0 +1,201 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/balance"
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+)
+
+// Memory is an in-memory implementation of [OfferingRevisionsStore], [ExchangesStore] and [BalancesStore].
+// It is safe for concurrent use.
+type Memory struct {
+	mu          sync.RWMutex
+	offerings   map[string]*offering.History
+	offeringIDs []string
+	exchanges   map[string]*tbdex.Exchange
+	exchangeIDs []string
+	balances    map[string][]balance.Balance
+}
+
+var (
+	_ OfferingRevisionsStore = (*Memory)(nil)
+	_ ExchangesStore         = (*Memory)(nil)
+	_ BalancesStore          = (*Memory)(nil)
+)
+
+// NewMemory creates an empty [Memory] store.
+func NewMemory() *Memory {
+	return &Memory{
+		offerings: make(map[string]*offering.History),
+		exchanges: make(map[string]*tbdex.Exchange),
+		balances:  make(map[string][]balance.Balance),
+	}
+}
+
+// GetOffering implements [OfferingsStore].
+func (m *Memory) GetOffering(ctx context.Context, id string) (offering.Offering, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	history, ok := m.offerings[id]
+	if !ok {
+		return offering.Offering{}, fmt.Errorf("offering %s: %w", id, ErrNotFound)
+	}
+
+	o, _ := history.Latest()
+
+	return o, nil
+}
+
+// GetOfferingRevisions implements [OfferingRevisionsStore].
+func (m *Memory) GetOfferingRevisions(ctx context.Context, id string) ([]offering.Offering, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	history, ok := m.offerings[id]
+	if !ok {
+		return nil, fmt.Errorf("offering %s: %w", id, ErrNotFound)
+	}
+
+	return history.Revisions(), nil
+}
+
+// GetOfferingAt implements [OfferingRevisionsStore].
+func (m *Memory) GetOfferingAt(ctx context.Context, id string, t time.Time) (offering.Offering, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	history, ok := m.offerings[id]
+	if !ok {
+		return offering.Offering{}, fmt.Errorf("offering %s: %w", id, ErrNotFound)
+	}
+
+	o, ok := history.At(t)
+	if !ok {
+		return offering.Offering{}, fmt.Errorf("offering %s at %s: %w", id, t.UTC().Format(time.RFC3339), ErrNotFound)
+	}
+
+	return o, nil
+}
+
+// GetOfferings implements [OfferingsStore].
+func (m *Memory) GetOfferings(ctx context.Context, page Page) ([]offering.Offering, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := paginate(m.offeringIDs, page)
+	offerings := make([]offering.Offering, 0, len(ids))
+	for _, id := range ids {
+		o, _ := m.offerings[id].Latest()
+		offerings = append(offerings, o)
+	}
+
+	return offerings, nil
+}
+
+// PutOffering implements [OfferingsStore]. The offering is stored as a new revision, see [OfferingRevisionsStore].
+func (m *Memory) PutOffering(ctx context.Context, o offering.Offering) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := o.Metadata.ID
+	history, ok := m.offerings[id]
+	if !ok {
+		history = &offering.History{}
+	}
+
+	if err := history.Add(o); err != nil {
+		return fmt.Errorf("failed to store offering %s: %w", id, err)
+	}
+
+	if !ok {
+		m.offerings[id] = history
+		m.offeringIDs = append(m.offeringIDs, id)
+	}
+
+	return nil
+}
+
+// AddMessage implements [ExchangesStore].
+func (m *Memory) AddMessage(ctx context.Context, msg tbdex.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exchangeID := msg.GetMetadata().ExchangeID
+
+	exchange, ok := m.exchanges[exchangeID]
+	if !ok {
+		exchange = &tbdex.Exchange{}
+	}
+
+	if err := exchange.Add(msg); err != nil {
+		return fmt.Errorf("failed to add %s to exchange %s: %w: %w", msg.GetKind(), exchangeID, ErrConflict, err)
+	}
+
+	if !ok {
+		m.exchanges[exchangeID] = exchange
+		m.exchangeIDs = append(m.exchangeIDs, exchangeID)
+	}
+
+	return nil
+}
+
+// GetExchange implements [ExchangesStore].
+func (m *Memory) GetExchange(ctx context.Context, exchangeID string) ([]tbdex.Message, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	exchange, ok := m.exchanges[exchangeID]
+	if !ok {
+		return nil, fmt.Errorf("exchange %s: %w", exchangeID, ErrNotFound)
+	}
+
+	return exchange.Messages(), nil
+}
+
+// GetExchangeIDs implements [ExchangesStore].
+func (m *Memory) GetExchangeIDs(ctx context.Context, filter ExchangesFilter, page Page) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var ids []string
+	for _, id := range m.exchangeIDs {
+		if filter.matches(id, m.exchanges[id]) {
+			ids = append(ids, id)
+		}
+	}
+
+	return paginate(ids, page), nil
+}
+
+// GetBalances implements [BalancesStore].
+func (m *Memory) GetBalances(ctx context.Context, customer string) ([]balance.Balance, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return slices.Clone(m.balances[customer]), nil
+}
+
+// PutBalance implements [BalancesStore].
+func (m *Memory) PutBalance(ctx context.Context, customer string, b balance.Balance) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	balances := m.balances[customer]
+	for i, existing := range balances {
+		if existing.Data.CurrencyCode == b.Data.CurrencyCode {
+			balances[i] = b
+			return nil
+		}
+	}
+
+	m.balances[customer] = append(balances, b)
+
+	return nil
+}
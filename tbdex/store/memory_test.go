@@ -0,0 +1,210 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/balance"
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/store"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/did"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func createRFQ(t *testing.T, walletDID, pfiDID did.BearerDID) rfq.RFQ {
+	t.Helper()
+
+	r, err := rfq.Create(
+		walletDID,
+		pfiDID.URI,
+		"offering_01hwztehxdezgajyyc95te7vbw",
+		rfq.Payin("100", "STORED_BALANCE"),
+		rfq.Payout("BANK_ACCOUNT"),
+	)
+	assert.NoError(t, err)
+
+	return r
+}
+
+func TestMemory_Offerings(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	s := store.NewMemory()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		o, err := offering.Create(
+			offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")}),
+			offering.NewPayout("MXN", []offering.PayoutMethod{offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour)}),
+			fmt.Sprintf("%d", i+1),
+			offering.NewCancellationDetails(false),
+			offering.From(pfiDID),
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, s.PutOffering(ctx, o))
+		ids = append(ids, o.Metadata.ID)
+	}
+
+	o, err := s.GetOffering(ctx, ids[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "2", o.Data.Rate)
+
+	_, err = s.GetOffering(ctx, "offering_01hwztehxdezgajyyc95te7vbw")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+
+	offerings, err := s.GetOfferings(ctx, store.Page{Offset: 1, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(offerings))
+	assert.Equal(t, ids[1], offerings[0].Metadata.ID)
+
+	offerings, err = s.GetOfferings(ctx, store.Page{Offset: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(offerings))
+
+	offerings, err = s.GetOfferings(ctx, store.Page{Offset: -1, Limit: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, len(ids), len(offerings))
+}
+
+func TestMemory_OfferingRevisions(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	s := store.NewMemory()
+
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	o, err := offering.Create(
+		offering.NewPayin("USD", []offering.PayinMethod{offering.NewPayinMethod("STORED_BALANCE")}),
+		offering.NewPayout("MXN", []offering.PayoutMethod{offering.NewPayoutMethod("BANK_ACCOUNT", time.Hour)}),
+		"17",
+		offering.NewCancellationDetails(false),
+		offering.CreatedAt(createdAt),
+		offering.UpdatedAt(createdAt),
+		offering.From(pfiDID),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, s.PutOffering(ctx, o))
+
+	revised := o
+	revised.Data.Rate = "16.5"
+	assert.NoError(t, revised.Revise(pfiDID, createdAt.Add(time.Hour)))
+	assert.NoError(t, s.PutOffering(ctx, revised))
+
+	// storing the previous revision again would go back in time
+	err = s.PutOffering(ctx, o)
+	assert.True(t, errors.Is(err, offering.ErrStaleRevision))
+
+	latest, err := s.GetOffering(ctx, o.Metadata.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "16.5", latest.Data.Rate)
+
+	revisions, err := s.GetOfferingRevisions(ctx, o.Metadata.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(revisions))
+
+	seen, err := s.GetOfferingAt(ctx, o.Metadata.ID, createdAt.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "17", seen.Data.Rate)
+
+	_, err = s.GetOfferingAt(ctx, o.Metadata.ID, createdAt.Add(-time.Minute))
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+
+	offerings, err := s.GetOfferings(ctx, store.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(offerings))
+}
+
+func TestMemory_Exchanges(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	otherWalletDID, _ := didjwk.Create()
+	s := store.NewMemory()
+
+	r1 := createRFQ(t, walletDID, pfiDID)
+	r2 := createRFQ(t, otherWalletDID, pfiDID)
+	assert.NoError(t, s.AddMessage(ctx, r1))
+	assert.NoError(t, s.AddMessage(ctx, r2))
+
+	// the exchange already exists
+	assert.True(t, errors.Is(s.AddMessage(ctx, r1), store.ErrConflict))
+
+	// order is not a valid next message after an rfq
+	o, err := order.Create(walletDID, pfiDID.URI, r1.Metadata.ExchangeID)
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(s.AddMessage(ctx, o), store.ErrConflict))
+
+	messages, err := s.GetExchange(ctx, r1.Metadata.ExchangeID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(messages))
+
+	_, err = s.GetExchange(ctx, "rfq_01hwztehxhe139magy0a18mzms")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+
+	ids, err := s.GetExchangeIDs(ctx, store.ExchangesFilter{}, store.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{r1.Metadata.ExchangeID, r2.Metadata.ExchangeID}, ids)
+
+	ids, err = s.GetExchangeIDs(ctx, store.ExchangesFilter{From: otherWalletDID.URI}, store.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{r2.Metadata.ExchangeID}, ids)
+
+	ids, err = s.GetExchangeIDs(ctx, store.ExchangesFilter{ExchangeIDs: []string{r1.Metadata.ExchangeID}}, store.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{r1.Metadata.ExchangeID}, ids)
+}
+
+func TestMemory_Balances(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	s := store.NewMemory()
+
+	usd, err := balance.Create(pfiDID, "USD", "100")
+	assert.NoError(t, err)
+	assert.NoError(t, s.PutBalance(ctx, walletDID.URI, usd))
+
+	usd, err = balance.Create(pfiDID, "USD", "50")
+	assert.NoError(t, err)
+	assert.NoError(t, s.PutBalance(ctx, walletDID.URI, usd))
+
+	balances, err := s.GetBalances(ctx, walletDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(balances))
+	assert.Equal(t, "50", balances[0].Data.Available)
+
+	balances, err = s.GetBalances(ctx, pfiDID.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(balances))
+}
+
+func TestMemory_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	s := store.NewMemory()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		r := createRFQ(t, walletDID, pfiDID)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.AddMessage(ctx, r))
+			_, err := s.GetExchangeIDs(ctx, store.ExchangesFilter{From: walletDID.URI}, store.Page{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	ids, err := s.GetExchangeIDs(ctx, store.ExchangesFilter{}, store.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(ids))
+}
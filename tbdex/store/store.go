@@ -0,0 +1,112 @@
+// Package store defines the persistence interfaces used by PFIs to store offerings, exchanges and balances,
+// along with implementations that can be used directly or as a reference.
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/balance"
+	"github.com/TBD54566975/tbdex-go/tbdex/offering"
+)
+
+// ErrNotFound is returned when the requested offering or exchange does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is returned when a message can't be added to its exchange in the exchange's current state, e.g.
+// because an rfq is added for an exchange that already exists.
+var ErrConflict = errors.New("conflict")
+
+// Page describes the requested page of a paginated list. A zero Limit means no limit. Negative values are
+// treated as zero.
+type Page struct {
+	Offset int
+	Limit  int
+}
+
+// ExchangesFilter narrows down the exchanges returned by [ExchangesStore.GetExchangeIDs].
+// Zero value fields are ignored.
+type ExchangesFilter struct {
+	// ExchangeIDs only includes exchanges with one of the given ids.
+	ExchangeIDs []string
+	// From only includes exchanges whose RFQ was sent by the given DID.
+	From string
+}
+
+// matches returns true if the exchange satisfies the filter.
+func (f ExchangesFilter) matches(id string, exchange *tbdex.Exchange) bool {
+	if len(f.ExchangeIDs) > 0 && !slices.Contains(f.ExchangeIDs, id) {
+		return false
+	}
+
+	if f.From != "" && exchange.Customer() != f.From {
+		return false
+	}
+
+	return true
+}
+
+// OfferingsStore persists offerings.
+type OfferingsStore interface {
+	// GetOffering returns the offering with the given id or [ErrNotFound].
+	GetOffering(ctx context.Context, id string) (offering.Offering, error)
+	// GetOfferings returns offerings in the order they were first stored.
+	GetOfferings(ctx context.Context, page Page) ([]offering.Offering, error)
+	// PutOffering stores the offering as the latest revision of the offering with the same id. The offering
+	// must be updated after the latest stored revision, otherwise [offering.ErrStaleRevision] is returned, so
+	// that an offering can't be replaced by an older or concurrent revision. This includes revisions updated
+	// within the same second, as timestamps have a resolution of 1 second. Storing the latest revision again
+	// is a no-op.
+	PutOffering(ctx context.Context, o offering.Offering) error
+}
+
+// OfferingRevisionsStore is an [OfferingsStore] that keeps every revision of its offerings, so that an RFQ can
+// be evaluated against the revision of the offering the customer saw.
+type OfferingRevisionsStore interface {
+	OfferingsStore
+	// GetOfferingRevisions returns every revision of the offering with the given id, oldest first,
+	// or [ErrNotFound].
+	GetOfferingRevisions(ctx context.Context, id string) ([]offering.Offering, error)
+	// GetOfferingAt returns the revision of the offering with the given id that was current at t or
+	// [ErrNotFound].
+	GetOfferingAt(ctx context.Context, id string, t time.Time) (offering.Offering, error)
+}
+
+// ExchangesStore persists the messages of exchanges.
+type ExchangesStore interface {
+	// AddMessage appends the message to its exchange. The message must be a valid next message
+	// for the exchange as enforced by [tbdex.Exchange], otherwise [ErrConflict] is returned. Checking and
+	// appending is atomic, so of two concurrent messages only one can be added at a given position.
+	AddMessage(ctx context.Context, msg tbdex.Message) error
+	// GetExchange returns the messages of the exchange in the order they were added or [ErrNotFound].
+	GetExchange(ctx context.Context, exchangeID string) ([]tbdex.Message, error)
+	// GetExchangeIDs returns the ids of the exchanges matching the filter in the order they were created.
+	GetExchangeIDs(ctx context.Context, filter ExchangesFilter, page Page) ([]string, error)
+}
+
+// BalancesStore persists the balances a PFI holds on behalf of its customers.
+type BalancesStore interface {
+	// GetBalances returns the balances held on behalf of the given customer DID.
+	GetBalances(ctx context.Context, customer string) ([]balance.Balance, error)
+	// PutBalance stores the balance for the given customer DID, replacing any existing balance
+	// in the same currency.
+	PutBalance(ctx context.Context, customer string, b balance.Balance) error
+}
+
+// paginate returns the subset of items described by page.
+func paginate[T any](items []T, page Page) []T {
+	offset := max(page.Offset, 0)
+	if offset >= len(items) {
+		return []T{}
+	}
+
+	items = items[offset:]
+	if page.Limit > 0 && page.Limit < len(items) {
+		items = items[:page.Limit]
+	}
+
+	return items
+}
@@ -1,6 +1,7 @@
 package tbdex
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -12,8 +13,12 @@ import (
 	liborderstatus "github.com/TBD54566975/tbdex-go/tbdex/orderstatus"
 	libquote "github.com/TBD54566975/tbdex-go/tbdex/quote"
 	librfq "github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 )
 
+// Timestamp is the RFC 3339 date-time used by message and resource metadata and quote expiry.
+type Timestamp = timestamp.Timestamp
+
 // Message is the interface that all tbdex messages implement. Especially useful for decoding and parsing messages
 // when the kind of message is not known upfront.
 type Message interface {
@@ -22,13 +27,17 @@ type Message interface {
 	GetKind() string
 	GetMetadata() message.Metadata
 	IsValidNext(kind string) bool
-	// TODO: uncomment these once rfq has been refactored to separate privateStrict bool
-	// Verify() error
-	// Parse([]byte) (Message, error)
+	GetSignature() string
+	Verify() error
+	VerifyContext(ctx context.Context) error
 }
 
+// msg only decodes the kind so that any other invalid metadata, such as a malformed createdAt, is reported by
+// the validation of the message itself.
 type msg struct {
-	Metadata message.Metadata
+	Metadata struct {
+		Kind string `json:"kind"`
+	} `json:"metadata"`
 }
 
 // UnmarshalMessage unmarshals a message. It uses the metadata kind to determine the type of message.
@@ -104,6 +113,11 @@ func UnmarshalMessage(input []byte) (Message, error) {
 // parsing validates the message and verifies the integrity of the message which can lead to
 // a network request in order to resolve the signer's DID
 func ParseMessage(input []byte) (Message, error) {
+	return ParseMessageContext(context.Background(), input)
+}
+
+// ParseMessageContext is like [ParseMessage] but uses ctx when verifying the message's signature.
+func ParseMessageContext(ctx context.Context, input []byte) (Message, error) {
 	var m msg
 	err := json.Unmarshal(input, &m)
 
@@ -114,21 +128,21 @@ func ParseMessage(input []byte) (Message, error) {
 	switch m.Metadata.Kind {
 	case librfq.Kind:
 
-		rfq, err := librfq.Parse(input)
+		rfq, err := librfq.ParseContext(ctx, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse rfq: %w", err)
 		}
 
 		return rfq, nil
 	case libquote.Kind:
-		quote, err := libquote.Parse(input)
+		quote, err := libquote.ParseContext(ctx, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse quote: %w", err)
 		}
 
 		return quote, nil
 	case liborder.Kind:
-		order, err := liborder.Parse(input)
+		order, err := liborder.ParseContext(ctx, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse order: %w", err)
 		}
@@ -136,7 +150,7 @@ func ParseMessage(input []byte) (Message, error) {
 		return order, nil
 
 	case liborderinstructions.Kind:
-		orderInstructions, err := liborderinstructions.Parse(input)
+		orderInstructions, err := liborderinstructions.ParseContext(ctx, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse order: %w", err)
 		}
@@ -144,22 +158,22 @@ func ParseMessage(input []byte) (Message, error) {
 		return orderInstructions, nil
 
 	case liborderstatus.Kind:
-		var orderStatus liborderstatus.OrderStatus
-		if err := json.Unmarshal(input, &orderStatus); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal order: %w", err)
+		orderStatus, err := liborderstatus.ParseContext(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse orderstatus: %w", err)
 		}
 
 		return orderStatus, nil
 
 	case libclose.Kind:
-		closemsg, err := libclose.Parse(input)
+		closemsg, err := libclose.ParseContext(ctx, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse close: %w", err)
 		}
 
 		return closemsg, nil
 	case libcancel.Kind:
-		cancel, err := libcancel.Parse(input)
+		cancel, err := libcancel.ParseContext(ctx, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse cancel: %w", err)
 		}
@@ -1,6 +1,10 @@
 package tbdex_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/TBD54566975/tbdex-go/tbdex"
@@ -10,8 +14,12 @@ import (
 	"github.com/TBD54566975/tbdex-go/tbdex/orderinstructions"
 	"github.com/TBD54566975/tbdex-go/tbdex/orderstatus"
 	"github.com/TBD54566975/tbdex-go/tbdex/quote"
+	"github.com/TBD54566975/tbdex-go/tbdex/resolver"
 	"github.com/TBD54566975/tbdex-go/tbdex/rfq"
+	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didcore"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
 )
 
 func TestParseMessage(t *testing.T) {
@@ -159,3 +167,122 @@ func TestUnmarshalMessage(t *testing.T) {
 		assert.NotZero(t, cancel)
 	})
 }
+
+func TestUnmarshalMessage_InvalidExpiresAt(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	q := createQuote(t, pfiDID, walletDID.URI, "rfq_01j3erbrnyf4yr5p7xwm0b7dk3")
+
+	var generic map[string]any
+	data, _ := json.Marshal(q)
+	assert.NoError(t, json.Unmarshal(data, &generic))
+	generic["data"].(map[string]any)["expiresAt"] = "tomorrow"
+
+	data, err := json.Marshal(generic)
+	assert.NoError(t, err)
+
+	_, err = tbdex.UnmarshalMessage(data)
+
+	var verr *validator.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "/data/expiresAt", verr.Details[0].Pointer)
+}
+
+func TestUnmarshalMessage_Concurrent(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+	exchangeID := r.Metadata.ExchangeID
+
+	o, err := order.Create(walletDID, pfiDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	oi, err := orderinstructions.Create(
+		pfiDID,
+		walletDID.URI,
+		exchangeID,
+		orderinstructions.PayinInstruction(orderinstructions.Link("https://example.com/payin")),
+		orderinstructions.PayoutInstruction(orderinstructions.Instruction("sent to your bank account")),
+	)
+	assert.NoError(t, err)
+
+	os, err := orderstatus.Create(pfiDID, walletDID.URI, exchangeID, orderstatus.PAYIN_INITIATED)
+	assert.NoError(t, err)
+
+	cl, err := closemsg.Create(pfiDID, walletDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	c, err := cancel.Create(walletDID, pfiDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	messages := []tbdex.Message{r, createQuote(t, pfiDID, walletDID.URI, exchangeID), o, oi, os, cl, c}
+
+	var inputs [][]byte
+	for _, msg := range messages {
+		data, err := json.Marshal(msg)
+		assert.NoError(t, err)
+		inputs = append(inputs, data)
+	}
+
+	var wg sync.WaitGroup
+	for range 16 {
+		for i, input := range inputs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				msg, err := tbdex.UnmarshalMessage(input)
+				assert.NoError(t, err)
+				assert.Equal(t, messages[i].GetKind(), msg.GetKind())
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestParseMessageContext_Canceled(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, err := order.Create(walletDID, pfiDID.URI, "rfq_01hwr7hh26e5s8fmebhrd29n2c")
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(o)
+	assert.NoError(t, err)
+
+	resolver.SetDefault(resolver.Func(func(ctx context.Context, uri string) (didcore.ResolutionResult, error) {
+		if err := ctx.Err(); err != nil {
+			return didcore.ResolutionResult{}, err
+		}
+
+		return resolver.Network.Resolve(ctx, uri)
+	}))
+	defer resolver.SetDefault(nil)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	_, err = tbdex.ParseMessageContext(ctx, data)
+	assert.IsError(t, err, context.Canceled)
+
+	_, err = tbdex.ParseMessageContext(context.Background(), data)
+	assert.NoError(t, err)
+}
+
+func TestMessage_Verify(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	o, err := order.Create(walletDID, pfiDID.URI, "rfq_01hwr7hh26e5s8fmebhrd29n2c")
+	assert.NoError(t, err)
+
+	var msg tbdex.Message = o
+	assert.Equal(t, o.Signature, msg.GetSignature())
+	assert.NoError(t, msg.Verify())
+
+	o.Metadata.ExchangeID = "rfq_01hwr7hh26e5s8fmebhrd29n2d"
+	msg = o
+	assert.Error(t, msg.Verify())
+}
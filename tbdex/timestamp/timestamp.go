@@ -0,0 +1,79 @@
+// Package timestamp provides [Timestamp], the RFC 3339 date-time used by tbDEX messages and resources e.g.
+// createdAt, updatedAt and expiresAt.
+package timestamp
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Timestamp is an RFC 3339 date-time. A Timestamp holds the exact string it was created or unmarshaled from,
+// so that it marshals to the same string and signature digests remain stable, including any sub-second
+// precision provided by the sender. The zero value is an empty timestamp.
+type Timestamp string
+
+// New returns the timestamp of t in UTC with a resolution of 1 second, which is how timestamps are formatted
+// by tbdex-go.
+func New(t time.Time) Timestamp {
+	return Timestamp(t.UTC().Format(time.RFC3339))
+}
+
+// Parse parses an RFC 3339 date-time, keeping s as is.
+func Parse(s string) (Timestamp, error) {
+	ts := Timestamp(s)
+	if err := ts.Validate(); err != nil {
+		return "", err
+	}
+
+	return ts, nil
+}
+
+// Validate returns an error if the timestamp is not a valid RFC 3339 date-time.
+func (ts Timestamp) Validate() error {
+	if _, err := time.Parse(time.RFC3339, string(ts)); err != nil {
+		return fmt.Errorf("invalid timestamp %q: %w", string(ts), err)
+	}
+
+	return nil
+}
+
+// Time returns the timestamp as a [time.Time]. The zero time is returned if the timestamp is empty or invalid.
+func (ts Timestamp) Time() time.Time {
+	t, err := time.Parse(time.RFC3339, string(ts))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+// IsZero returns true if the timestamp is empty.
+func (ts Timestamp) IsZero() bool {
+	return ts == ""
+}
+
+func (ts Timestamp) String() string {
+	return string(ts)
+}
+
+// UnmarshalJSON unmarshals a JSON string, returning an error if it isn't a valid RFC 3339 date-time.
+func (ts *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to unmarshal timestamp: %w", err)
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*ts = parsed
+
+	return nil
+}
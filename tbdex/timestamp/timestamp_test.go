@@ -0,0 +1,84 @@
+package timestamp_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestNew(t *testing.T) {
+	ts := timestamp.New(time.Date(2024, 5, 1, 12, 0, 0, 500, time.FixedZone("EAT", 3*60*60)))
+	assert.Equal(t, timestamp.Timestamp("2024-05-01T09:00:00Z"), ts)
+	assert.Equal(t, time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), ts.Time())
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	for _, input := range []string{
+		`"2024-05-01T09:00:00Z"`,
+		`"2024-05-01T09:00:00.563160Z"`,
+		`"2024-05-01T12:00:00.5+03:00"`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			var ts timestamp.Timestamp
+			assert.NoError(t, json.Unmarshal([]byte(input), &ts))
+
+			// marshals back to the exact input so that digests are unaffected
+			data, err := json.Marshal(ts)
+			assert.NoError(t, err)
+			assert.Equal(t, input, string(data))
+		})
+	}
+
+	var ts timestamp.Timestamp
+	assert.NoError(t, json.Unmarshal([]byte(`"2024-05-01T09:00:00.563160Z"`), &ts))
+	assert.Equal(t, 563160000, ts.Time().Nanosecond())
+}
+
+func TestUnmarshalJSON_Invalid(t *testing.T) {
+	for _, input := range []string{
+		`""`,
+		`"not a timestamp"`,
+		`"2024-05-01"`,
+		`"2024-05-01T09:00:00"`,
+		`"2024-13-01T09:00:00Z"`,
+		`1714554000`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			var ts timestamp.Timestamp
+			assert.Error(t, json.Unmarshal([]byte(input), &ts))
+		})
+	}
+}
+
+func TestUnmarshalJSON_Struct(t *testing.T) {
+	var metadata struct {
+		CreatedAt timestamp.Timestamp `json:"createdAt"`
+		UpdatedAt timestamp.Timestamp `json:"updatedAt,omitempty"`
+	}
+
+	err := json.Unmarshal([]byte(`{"createdAt":"yesterday"}`), &metadata)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`{"createdAt":"2024-05-01T09:00:00Z"}`), &metadata)
+	assert.NoError(t, err)
+	assert.True(t, metadata.UpdatedAt.IsZero())
+
+	data, err := json.Marshal(metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"createdAt":"2024-05-01T09:00:00Z"}`, string(data))
+}
+
+func TestParse(t *testing.T) {
+	ts, err := timestamp.Parse("2024-05-01T09:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-05-01T09:00:00Z", ts.String())
+
+	_, err = timestamp.Parse("2024-05-01 09:00:00")
+	assert.Error(t, err)
+
+	assert.Error(t, timestamp.Timestamp("").Validate())
+	assert.True(t, timestamp.Timestamp("garbage").Time().IsZero())
+}
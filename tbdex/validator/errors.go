@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// ValidationError is returned by [Validate] when the input does not satisfy the tbdex JSON schemas.
+// It can be retrieved from errors returned by UnmarshalJSON and Parse using [errors.As].
+type ValidationError struct {
+	Details []ValidationErrorDetail
+}
+
+// ValidationErrorDetail describes a single schema violation.
+type ValidationErrorDetail struct {
+	// Pointer is a JSON pointer to the invalid value within the input e.g. /data/payin/amount.
+	// An empty Pointer refers to the whole input.
+	Pointer string
+	// Keyword is the schema keyword that failed e.g. required
+	Keyword string
+	// Message describes the violation
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Details))
+	for _, d := range e.Details {
+		pointer := d.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+
+		msgs = append(msgs, pointer+": "+d.Message)
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// newValidationError flattens the causes of a jsonschema validation error into a [ValidationError].
+// prefix is prepended to every pointer, which is needed when validating a value nested within the input.
+// Errors that aren't jsonschema validation errors are returned as is.
+func newValidationError(err error, prefix string) error {
+	var schemaErr *jsonschema.ValidationError
+	if !errors.As(err, &schemaErr) {
+		return err
+	}
+
+	verr := &ValidationError{}
+	flattenSchemaError(schemaErr, prefix, verr)
+
+	return verr
+}
+
+// flattenSchemaError appends a detail for every leaf cause of err.
+func flattenSchemaError(err *jsonschema.ValidationError, prefix string, verr *ValidationError) {
+	if len(err.Causes) > 0 {
+		for _, cause := range err.Causes {
+			flattenSchemaError(cause, prefix, verr)
+		}
+
+		return
+	}
+
+	keyword := err.KeywordLocation
+	if i := strings.LastIndex(keyword, "/"); i >= 0 {
+		keyword = keyword[i+1:]
+	}
+
+	verr.Details = append(verr.Details, ValidationErrorDetail{
+		Pointer: prefix + err.InstanceLocation,
+		Keyword: keyword,
+		Message: err.Message,
+	})
+}
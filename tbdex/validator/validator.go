@@ -1,11 +1,18 @@
 package validator
 
 import (
+	"cmp"
 	"embed"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/TBD54566975/tbdex-go/tbdex/timestamp"
 	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
@@ -16,39 +23,228 @@ const (
 	TypeResource      DataType = "resource" // TypeResource represents tbdex resource
 	TypeMessage       DataType = "message"  // TypeMessage represents tbdex message
 	definitionsSchema          = "definitions.json"
-	schemaDir                  = "json-schemas/"
+	schemaDir                  = "json-schemas"
 	schemaHost                 = "https://tbdex.dev/"
 	schemaExtension            = ".schema.json"
 )
 
+// DefaultProtocol is the protocol version used to validate input that does not specify one.
+const DefaultProtocol = "1.0"
+
+// dateTimes lists the pointers of the date-time values of each data type and kind. The tbdex schemas only
+// describe their format, so they are checked after schema validation.
+var dateTimes = map[string][]string{
+	string(TypeResource): {"/metadata/createdAt", "/metadata/updatedAt"},
+	string(TypeMessage):  {"/metadata/createdAt"},
+	"quote":              {"/data/expiresAt"},
+}
+
+// The schemas of each supported protocol version are embedded in a directory named after the version. A version's
+// directory only contains the schemas that changed since the previous version, which is how `just schemas`
+// copies them, and inherits the rest.
+//
 //go:embed json-schemas
 var embeddedSchemas embed.FS
-var schemaCompiler *jsonschema.Compiler
-var schemaMap map[string]*jsonschema.Schema = make(map[string]*jsonschema.Schema)
-
-// init does the following:
-//   - initializes the schema compiler
-//   - loads the shared definitions schema
-//   - loads the resource and message schemas
-func init() {
-	schemaCompiler = jsonschema.NewCompiler()
-	schemaCompiler.Draft = jsonschema.Draft7
-
-	definitions, err := embeddedSchemas.Open(schemaDir + definitionsSchema)
+
+// defaultValidator is used by [Validate].
+var defaultValidator = mustNew()
+
+type options struct {
+	schemas fs.FS
+}
+
+// Option implements functional options pattern for [New].
+type Option func(*options)
+
+// SchemaFS can be passed to [New] to compile the schemas in fsys instead of the embedded schemas. fsys must
+// be laid out like the embedded schemas: a directory per protocol version at its root, each containing the
+// schemas that changed since the previous version.
+func SchemaFS(fsys fs.FS) Option {
+	return func(o *options) {
+		o.schemas = fsys
+	}
+}
+
+// Validator validates tbdex resources and messages against the embedded JSON schemas of the protocol version
+// found in their metadata. Every schema is compiled when the Validator is created, after which the Validator
+// is read-only and safe for concurrent use.
+type Validator struct {
+	// schemas maps protocol versions to their schemas keyed by name
+	schemas   map[string]map[string]*jsonschema.Schema
+	protocols []string
+}
+
+// New creates a [Validator] by compiling the shared definitions schema along with every resource, message and
+// kind-specific schema of every supported protocol version.
+func New(opts ...Option) (*Validator, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	fsys := o.schemas
+	if fsys == nil {
+		var err error
+		if fsys, err = fs.Sub(embeddedSchemas, schemaDir); err != nil {
+			return nil, fmt.Errorf("failed to open embedded schemas: %w", err)
+		}
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to list protocol versions: %w", err)
+	}
+
+	var protocols []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			protocols = append(protocols, entry.Name())
+		}
+	}
+
+	slices.SortFunc(protocols, compareProtocols)
+
+	v := &Validator{schemas: make(map[string]map[string]*jsonschema.Schema), protocols: protocols}
+
+	// files holds the path of the latest revision of each schema file as of the version being compiled
+	files := make(map[string]string)
+	for _, protocol := range protocols {
+		entries, err := fs.ReadDir(fsys, protocol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s schema files: %w", protocol, err)
+		}
+
+		for _, entry := range entries {
+			files[entry.Name()] = path.Join(protocol, entry.Name())
+		}
+
+		schemas, err := compileSchemas(fsys, files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile protocol %s schemas: %w", protocol, err)
+		}
+
+		v.schemas[protocol] = schemas
+	}
+
+	if _, ok := v.schemas[DefaultProtocol]; !ok {
+		return nil, fmt.Errorf("missing protocol %s schemas", DefaultProtocol)
+	}
+
+	return v, nil
+}
+
+// compileSchemas compiles the given schema files, keyed by file name, using a dedicated compiler so that the
+// schemas of different protocol versions, which share ids, don't conflict.
+func compileSchemas(fsys fs.FS, files map[string]string) (map[string]*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+
+	definitionsPath, ok := files[definitionsSchema]
+	if !ok {
+		return nil, errors.New("missing definitions schema")
 	}
 
-	err = schemaCompiler.AddResource(schemaHost+definitionsSchema, definitions)
+	definitions, err := fsys.Open(definitionsPath)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to load definitions schema: %w", err)
 	}
 
-	for _, schemaName := range []DataType{TypeResource, TypeMessage} {
-		_, err = loadSchema(string(schemaName))
+	err = compiler.AddResource(schemaHost+definitionsSchema, definitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add definitions schema as resource: %w", err)
+	}
+
+	schemas := make(map[string]*jsonschema.Schema)
+	for fileName, schemaPath := range files {
+		schemaName, ok := strings.CutSuffix(fileName, schemaExtension)
+		if !ok {
+			continue
+		}
+
+		schema, err := loadSchema(fsys, compiler, schemaPath)
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("failed to load %s schema: %w", schemaName, err)
+		}
+
+		schemas[schemaName] = schema
+	}
+
+	for _, schemaName := range []DataType{TypeResource, TypeMessage} {
+		if _, ok := schemas[string(schemaName)]; !ok {
+			return nil, fmt.Errorf("missing %s schema", schemaName)
+		}
+	}
+
+	return schemas, nil
+}
+
+// mustNew is like [New] but panics if the embedded schemas cannot be compiled.
+func mustNew() *Validator {
+	v, err := New()
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// Protocols returns the protocol versions supported by the validator in ascending order.
+func (val *Validator) Protocols() []string {
+	return slices.Clone(val.protocols)
+}
+
+// SupportsProtocol returns true if the validator has schemas for the given protocol version.
+func (val *Validator) SupportsProtocol(protocol string) bool {
+	_, ok := val.schemas[protocol]
+	return ok
+}
+
+// Protocols returns the protocol versions supported by the default [Validator] in ascending order.
+func Protocols() []string {
+	return defaultValidator.Protocols()
+}
+
+// SupportsProtocol returns true if the default [Validator] supports the given protocol version.
+func SupportsProtocol(protocol string) bool {
+	return defaultValidator.SupportsProtocol(protocol)
+}
+
+// parseProtocol parses a "major.minor" protocol version.
+func parseProtocol(protocol string) (major, minor int, ok bool) {
+	majorStr, minorStr, found := strings.Cut(protocol, ".")
+	if !found {
+		return 0, 0, false
+	}
+
+	major, err := strconv.Atoi(majorStr)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	minor, err = strconv.Atoi(minorStr)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return major, minor, true
+}
+
+// compareProtocols compares two protocol versions. Invalid versions are ordered before valid versions.
+func compareProtocols(a, b string) int {
+	aMajor, aMinor, aOK := parseProtocol(a)
+	bMajor, bMinor, bOK := parseProtocol(b)
+
+	switch {
+	case aOK != bOK:
+		if aOK {
+			return 1
 		}
+
+		return -1
+	case aMajor != bMajor:
+		return cmp.Compare(aMajor, bMajor)
+	default:
+		return cmp.Compare(aMinor, bMinor)
 	}
 }
 
@@ -66,7 +262,12 @@ func WithKind(kind string) ValidateOption {
 	}
 }
 
-// Validate validates the input provided in two phases:
+// Validate validates the input using a default [Validator]. See [Validator.Validate].
+func Validate(dataType DataType, input []byte, opts ...ValidateOption) error {
+	return defaultValidator.Validate(dataType, input, opts...)
+}
+
+// Validate validates the input against the schemas of the protocol version in its metadata in two phases:
 //  1. Validate the general structure of the resource or message based on the Type.
 //  2. Validate the specific structure of the resource or message based on the Kind.
 //
@@ -74,11 +275,8 @@ func WithKind(kind string) ValidateOption {
 // what was provided. This is useful when the Kind is known ahead of time. If the Kind is not
 // specified, validation will proceed to phase 2 using metadata.kind.
 //
-// # Note
-//
-// Kind-specific schemas are lazily loaded the first time they are needed and then
-// cached for future use.
-func Validate(dataType DataType, input []byte, opts ...ValidateOption) error {
+// Date-times such as metadata.createdAt are checked to be RFC 3339 in the phase that validates them.
+func (val *Validator) Validate(dataType DataType, input []byte, opts ...ValidateOption) error {
 	var options validateOptions
 	for _, o := range opts {
 		o(&options)
@@ -90,63 +288,134 @@ func Validate(dataType DataType, input []byte, opts ...ValidateOption) error {
 		return fmt.Errorf("failed to JSON unmarshal input: %w", err)
 	}
 
-	typeSchema := schemaMap[string(dataType)]
+	// input that doesn't specify a protocol is validated against the default protocol's schemas, which
+	// will report the missing protocol
+	entity, _ := v.(map[string]any)
+	metadata, _ := entity["metadata"].(map[string]any)
+	protocol, _ := metadata["protocol"].(string)
+
+	schemas := val.schemas[DefaultProtocol]
+	if protocol != "" {
+		var ok bool
+		if schemas, ok = val.schemas[protocol]; !ok {
+			return fmt.Errorf("failed to validate input: %w", &ValidationError{Details: []ValidationErrorDetail{{
+				Pointer: "/metadata/protocol",
+				Keyword: "enum",
+				Message: fmt.Sprintf("unsupported protocol %s, supported: %s", protocol, strings.Join(val.protocols, ", ")),
+			}}})
+		}
+	}
+
+	typeSchema, ok := schemas[string(dataType)]
+	if !ok {
+		return fmt.Errorf("unknown data type: %s", dataType)
+	}
+
 	err = typeSchema.Validate(v)
 	if err != nil {
+		return fmt.Errorf("failed to validate input: %w", newValidationError(err, ""))
+	}
+
+	if err := validateDateTimes(v, dateTimes[string(dataType)]); err != nil {
 		return fmt.Errorf("failed to validate input: %w", err)
 	}
 
-	entity, ok := v.(map[string]any)
-	if !ok {
-		return fmt.Errorf("expected input to be an object: %w", err)
+	if entity == nil {
+		return errors.New("expected input to be an object")
 	}
 
-	metadata, _ := entity["metadata"].(map[string]any)
 	kind, _ := metadata["kind"].(string)
 
 	if options.kind != "" && kind != options.kind {
-		return errors.New("kind mismatch")
+		return fmt.Errorf("kind mismatch: %w", &ValidationError{Details: []ValidationErrorDetail{{
+			Pointer: "/metadata/kind",
+			Keyword: "const",
+			Message: fmt.Sprintf("expected %s but found %s", options.kind, kind),
+		}}})
 	}
 
-	kindSchema, err := loadSchema(kind)
-	if err != nil {
-		return fmt.Errorf("failed to validate input: %w", err)
+	kindSchema, ok := schemas[kind]
+	if !ok {
+		return fmt.Errorf("failed to validate input: %w", &ValidationError{Details: []ValidationErrorDetail{{
+			Pointer: "/metadata/kind",
+			Keyword: "enum",
+			Message: fmt.Sprintf("unknown kind %s", kind),
+		}}})
 	}
 
 	err = kindSchema.Validate(entity["data"])
 	if err != nil {
+		return fmt.Errorf("failed to validate input: %w", newValidationError(err, "/data"))
+	}
+
+	if err := validateDateTimes(v, dateTimes[kind]); err != nil {
 		return fmt.Errorf("failed to validate input: %w", err)
 	}
 
 	return nil
 }
 
-// loadSchema loads the schema with the given name. If the schema has already been loaded,
-// it is returned from the cache.
-func loadSchema(schemaName string) (*jsonschema.Schema, error) {
-	schema, ok := schemaMap[schemaName]
-	if ok {
-		return schema, nil
+// validateDateTimes returns a [ValidationError] for every value at the given pointers that isn't an RFC 3339
+// date-time. Missing values are skipped, as they are reported by the schemas if required.
+func validateDateTimes(input any, pointers []string) error {
+	verr := &ValidationError{}
+	for _, pointer := range pointers {
+		value, ok := lookup(input, pointer)
+		if !ok {
+			continue
+		}
+
+		s, _ := value.(string)
+		if _, err := timestamp.Parse(s); err != nil {
+			verr.Details = append(verr.Details, ValidationErrorDetail{
+				Pointer: pointer,
+				Keyword: "format",
+				Message: fmt.Sprintf("%v is not a valid date-time", value),
+			})
+		}
+	}
+
+	if len(verr.Details) > 0 {
+		return verr
 	}
 
-	schemaPath := schemaDir + schemaName + schemaExtension
-	schemaFile, err := embeddedSchemas.Open(schemaPath)
+	return nil
+}
+
+// lookup returns the value at the given JSON pointer, which must only reference object members.
+func lookup(input any, pointer string) (any, bool) {
+	value := input
+	for _, key := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if value, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// loadSchema adds the schema at the given path to the compiler and compiles it.
+func loadSchema(fsys fs.FS, compiler *jsonschema.Compiler, schemaPath string) (*jsonschema.Schema, error) {
+	schemaFile, err := fsys.Open(schemaPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load schema file: %w", err)
 	}
 
-	schemaURL := schemaHost + schemaPath
-	err = schemaCompiler.AddResource(schemaURL, schemaFile)
+	schemaURL := schemaHost + path.Base(schemaPath)
+	err = compiler.AddResource(schemaURL, schemaFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add schema as resource: %w", err)
 	}
 
-	schema, err = schemaCompiler.Compile(schemaURL)
+	schema, err := compiler.Compile(schemaURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile schema: %w", err)
 	}
 
-	schemaMap[schemaName] = schema
-
 	return schema, nil
 }
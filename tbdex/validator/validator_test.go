@@ -1,13 +1,160 @@
 package validator_test
 
 import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
 	"testing"
+	"testing/fstest"
 
+	"github.com/TBD54566975/tbdex-go/tbdex/cancel"
+	"github.com/TBD54566975/tbdex-go/tbdex/closemsg"
 	"github.com/TBD54566975/tbdex-go/tbdex/validator"
 	"github.com/alecthomas/assert"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
 )
 
 func TestValidate_Invalid(t *testing.T) {
 	err := validator.Validate(validator.TypeResource, []byte(`{"foo": "bar"}`))
 	assert.Error(t, err)
 }
+
+func TestValidate_ValidationError(t *testing.T) {
+	err := validator.Validate(validator.TypeMessage, []byte(`{"metadata": {}, "data": {}}`))
+
+	var verr *validator.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.NotZero(t, verr.Details)
+
+	for _, d := range verr.Details {
+		assert.NotZero(t, d.Keyword)
+		assert.NotZero(t, d.Message)
+	}
+}
+
+func TestValidate_InvalidDateTime(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	c, err := closemsg.Create(pfiDID, walletDID.URI, "exchange")
+	assert.NoError(t, err)
+
+	var generic map[string]any
+	assert.NoError(t, json.Unmarshal(withProtocol(t, c, "1.0"), &generic))
+	generic["metadata"].(map[string]any)["createdAt"] = "2024-13-01"
+
+	data, err := json.Marshal(generic)
+	assert.NoError(t, err)
+
+	err = validator.Validate(validator.TypeMessage, data)
+
+	var verr *validator.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, []validator.ValidationErrorDetail{{
+		Pointer: "/metadata/createdAt",
+		Keyword: "format",
+		Message: "2024-13-01 is not a valid date-time",
+	}}, verr.Details)
+}
+
+func TestNew_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			v, err := validator.New()
+			assert.NoError(t, err)
+
+			err = v.Validate(validator.TypeMessage, []byte(`{"foo": "bar"}`))
+			assert.Error(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestValidate_UnsupportedProtocol(t *testing.T) {
+	err := validator.Validate(validator.TypeMessage, []byte(`{"metadata": {"protocol": "0.9"}, "data": {}}`))
+
+	var verr *validator.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "/metadata/protocol", verr.Details[0].Pointer)
+}
+
+func TestProtocols(t *testing.T) {
+	assert.Equal(t, []string{"1.0"}, validator.Protocols())
+	assert.True(t, validator.SupportsProtocol("1.0"))
+	assert.False(t, validator.SupportsProtocol("1.1"))
+}
+
+func TestNew_SchemaFS(t *testing.T) {
+	schemas := fstest.MapFS{}
+	err := fs.WalkDir(os.DirFS("json-schemas"), "1.0", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := os.ReadFile("json-schemas/" + path)
+		schemas[path] = &fstest.MapFile{Data: data}
+		return err
+	})
+	assert.NoError(t, err)
+
+	// 2.0 only overrides the close schema, making the reason required, and inherits the rest from 1.0
+	schemas["2.0/close.schema.json"] = &fstest.MapFile{Data: []byte(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"$id": "https://tbdex.dev/close.schema.json",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["reason"],
+		"properties": {
+			"reason": {"type": "string"},
+			"success": {"type": "boolean"}
+		}
+	}`)}
+
+	v, err := validator.New(validator.SchemaFS(schemas))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.0", "2.0"}, v.Protocols())
+
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	c, err := closemsg.Create(pfiDID, walletDID.URI, "exchange")
+	assert.NoError(t, err)
+
+	assert.NoError(t, v.Validate(validator.TypeMessage, withProtocol(t, c, "1.0")))
+	assert.Error(t, v.Validate(validator.TypeMessage, withProtocol(t, c, "2.0")))
+
+	cancelled, err := cancel.Create(walletDID, pfiDID.URI, "exchange", cancel.Reason("changed my mind"))
+	assert.NoError(t, err)
+	assert.NoError(t, v.Validate(validator.TypeMessage, withProtocol(t, cancelled, "2.0")))
+
+	// the inherited cancel schema is still enforced
+	var generic map[string]any
+	assert.NoError(t, json.Unmarshal(withProtocol(t, cancelled, "2.0"), &generic))
+	generic["data"] = map[string]any{"foo": "bar"}
+	invalid, err := json.Marshal(generic)
+	assert.NoError(t, err)
+	assert.Error(t, v.Validate(validator.TypeMessage, invalid))
+}
+
+// withProtocol marshals msg with the given protocol version.
+func withProtocol(t *testing.T, msg any, protocol string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(msg)
+	assert.NoError(t, err)
+
+	var generic map[string]any
+	assert.NoError(t, json.Unmarshal(data, &generic))
+	generic["metadata"].(map[string]any)["protocol"] = protocol
+
+	data, err = json.Marshal(generic)
+	assert.NoError(t, err)
+
+	return data
+}
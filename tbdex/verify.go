@@ -0,0 +1,224 @@
+package tbdex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	librfq "github.com/TBD54566975/tbdex-go/tbdex/rfq"
+)
+
+// ExchangeCheckName identifies a check performed by [VerifyExchange].
+type ExchangeCheckName string
+
+const (
+	CheckStartsWithRFQ ExchangeCheckName = "startsWithRfq" // CheckStartsWithRFQ checks that the first message is an RFQ
+	CheckSignature     ExchangeCheckName = "signature"     // CheckSignature checks the message's signature
+	CheckExchangeID    ExchangeCheckName = "exchangeId"    // CheckExchangeID checks the message shares the RFQ's exchange id
+	CheckParticipants  ExchangeCheckName = "participants"  // CheckParticipants checks the message is between the RFQ's customer and PFI
+	CheckProtocol      ExchangeCheckName = "protocol"      // CheckProtocol checks the message shares the RFQ's protocol version
+	CheckUniqueID      ExchangeCheckName = "uniqueId"      // CheckUniqueID checks no other message in the exchange has the same id
+	CheckCreatedAt     ExchangeCheckName = "createdAt"     // CheckCreatedAt checks the message was not created before the previous message
+	CheckSequence      ExchangeCheckName = "sequence"      // CheckSequence checks the message kind can follow the previous message
+)
+
+// ExchangeCheck is the outcome of a single check performed by [VerifyExchange].
+type ExchangeCheck struct {
+	Name ExchangeCheckName
+	// Index is the position of the checked message within the verified messages.
+	Index     int
+	MessageID string
+	Kind      string
+	// Err is nil if the check passed.
+	Err error
+}
+
+// Passed returns true if the check passed.
+func (c ExchangeCheck) Passed() bool {
+	return c.Err == nil
+}
+
+func (c ExchangeCheck) String() string {
+	status := "ok"
+	if c.Err != nil {
+		status = c.Err.Error()
+	}
+
+	return fmt.Sprintf("%s #%d %s (%s): %s", c.Name, c.Index, c.Kind, c.MessageID, status)
+}
+
+// MarshalJSON marshals the check including whether it passed and its error message, if any.
+func (c ExchangeCheck) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Name      ExchangeCheckName `json:"name"`
+		Index     int               `json:"index"`
+		MessageID string            `json:"messageId,omitempty"`
+		Kind      string            `json:"kind,omitempty"`
+		Passed    bool              `json:"passed"`
+		Error     string            `json:"error,omitempty"`
+	}{Name: c.Name, Index: c.Index, MessageID: c.MessageID, Kind: c.Kind, Passed: c.Passed()}
+
+	if c.Err != nil {
+		out.Error = c.Err.Error()
+	}
+
+	return json.Marshal(out)
+}
+
+// ExchangeReport is the itemised result of [VerifyExchange]. It lists every check performed, whether or not it
+// passed, and can be marshaled to JSON for audits.
+type ExchangeReport struct {
+	ExchangeID string          `json:"exchangeId"`
+	Checks     []ExchangeCheck `json:"checks"`
+}
+
+// OK returns true if every check passed.
+func (r ExchangeReport) OK() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns the checks that did not pass.
+func (r ExchangeReport) Failures() []ExchangeCheck {
+	var failures []ExchangeCheck
+	for _, c := range r.Checks {
+		if !c.Passed() {
+			failures = append(failures, c)
+		}
+	}
+
+	return failures
+}
+
+// Err returns the errors of the failed checks joined with [errors.Join]. nil if every check passed.
+func (r ExchangeReport) Err() error {
+	var errs []error
+	for _, c := range r.Failures() {
+		errs = append(errs, fmt.Errorf("%s check failed for %s #%d (%s): %w", c.Name, c.Kind, c.Index, c.MessageID, c.Err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// VerifyExchange verifies the messages of a single exchange, in the order they were sent, both individually and
+// against each other. Unlike [Exchange.Add], which stops at the first problem, every check is performed on every
+// message and the outcome of each is reported. The checks are:
+//   - the first message is an RFQ
+//   - every message's signature is valid
+//   - every message shares the RFQ's exchange id and protocol version
+//   - customer messages are sent from the RFQ's sender to its recipient, PFI messages the other way around
+//   - message ids are unique
+//   - created at times never decrease
+//   - every message kind is a valid next message after the previous message
+func VerifyExchange(messages []Message) ExchangeReport {
+	return VerifyExchangeContext(context.Background(), messages)
+}
+
+// VerifyExchangeContext is like [VerifyExchange] but uses ctx when resolving the signers' DIDs.
+func VerifyExchangeContext(ctx context.Context, messages []Message) ExchangeReport {
+	report := ExchangeReport{}
+
+	if len(messages) == 0 || messages[0] == nil {
+		report.Checks = append(report.Checks, ExchangeCheck{
+			Name: CheckStartsWithRFQ,
+			Err:  errors.New("exchange must start with an rfq"),
+		})
+
+		return report
+	}
+
+	first := messages[0].GetMetadata()
+	report.ExchangeID = first.ExchangeID
+
+	var rfqErr error
+	if _, ok := unwrapMessage(messages[0]).(librfq.RFQ); !ok {
+		rfqErr = fmt.Errorf("exchange must start with an rfq, got: %s", first.Kind)
+	}
+
+	report.Checks = append(report.Checks, ExchangeCheck{
+		Name:      CheckStartsWithRFQ,
+		MessageID: first.ID,
+		Kind:      first.Kind,
+		Err:       rfqErr,
+	})
+
+	customer, pfi := first.From, first.To
+	seenIDs := map[string]int{}
+
+	var previousCreatedAt time.Time
+	for i, m := range messages {
+		if m == nil {
+			report.Checks = append(report.Checks, ExchangeCheck{Name: CheckSignature, Index: i, Err: errors.New("message is nil")})
+			continue
+		}
+
+		metadata := m.GetMetadata()
+		check := func(name ExchangeCheckName, err error) {
+			report.Checks = append(report.Checks, ExchangeCheck{
+				Name:      name,
+				Index:     i,
+				MessageID: metadata.ID,
+				Kind:      metadata.Kind,
+				Err:       err,
+			})
+		}
+
+		check(CheckSignature, m.VerifyContext(ctx))
+
+		var err error
+		if metadata.ExchangeID != report.ExchangeID {
+			err = fmt.Errorf("exchange id: %s does not match exchange: %s", metadata.ExchangeID, report.ExchangeID)
+		}
+		check(CheckExchangeID, err)
+
+		from, to := pfi, customer
+		if isCustomerMessage(metadata.Kind) {
+			from, to = customer, pfi
+		}
+
+		err = nil
+		if metadata.From != from {
+			err = fmt.Errorf("must be sent from %s, got: %s", from, metadata.From)
+		} else if metadata.To != to {
+			err = fmt.Errorf("must be sent to %s, got: %s", to, metadata.To)
+		}
+		check(CheckParticipants, err)
+
+		err = nil
+		if metadata.Protocol != first.Protocol {
+			err = fmt.Errorf("protocol: %s does not match exchange protocol: %s", metadata.Protocol, first.Protocol)
+		}
+		check(CheckProtocol, err)
+
+		err = nil
+		if j, ok := seenIDs[metadata.ID]; ok {
+			err = fmt.Errorf("id %s is also used by message #%d", metadata.ID, j)
+		} else {
+			seenIDs[metadata.ID] = i
+		}
+		check(CheckUniqueID, err)
+
+		createdAt := metadata.CreatedAt.Time()
+		if err = metadata.CreatedAt.Validate(); err != nil {
+			err = fmt.Errorf("failed to parse created at: %w", err)
+		} else if createdAt.Before(previousCreatedAt) {
+			err = fmt.Errorf("created at %s is before the previous message", metadata.CreatedAt)
+		} else {
+			previousCreatedAt = createdAt
+		}
+		check(CheckCreatedAt, err)
+
+		if i > 0 && messages[i-1] != nil {
+			previous := messages[i-1]
+
+			err = nil
+			if !previous.IsValidNext(metadata.Kind) {
+				err = fmt.Errorf("%s is not a valid next message after %s. valid next: %v", metadata.Kind, previous.GetKind(), previous.GetValidNext())
+			}
+			check(CheckSequence, err)
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,99 @@
+package tbdex_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TBD54566975/tbdex-go/tbdex"
+	"github.com/TBD54566975/tbdex-go/tbdex/order"
+	"github.com/TBD54566975/tbdex-go/tbdex/orderstatus"
+	"github.com/alecthomas/assert/v2"
+	"github.com/tbd54566975/web5-go/dids/didjwk"
+)
+
+func TestVerifyExchange(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+	exchangeID := r.Metadata.ExchangeID
+
+	q := createQuote(t, pfiDID, walletDID.URI, exchangeID)
+
+	o, err := order.Create(walletDID, pfiDID.URI, exchangeID)
+	assert.NoError(t, err)
+
+	report := tbdex.VerifyExchange([]tbdex.Message{r, q, o})
+	assert.True(t, report.OK())
+	assert.NoError(t, report.Err())
+	assert.Equal(t, exchangeID, report.ExchangeID)
+	assert.NotZero(t, report.Checks)
+
+	data, err := json.Marshal(report)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"passed":true`)
+}
+
+func TestVerifyExchange_Failures(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+	otherDID, _ := didjwk.Create()
+
+	r := createRFQ(t, walletDID, pfiDID)
+	exchangeID := r.Metadata.ExchangeID
+
+	q := createQuote(t, pfiDID, walletDID.URI, exchangeID)
+
+	// sent by someone other than the customer, before the quote, reusing the quote's id
+	o, err := order.Create(
+		otherDID,
+		pfiDID.URI,
+		exchangeID,
+		order.ID(q.Metadata.ID),
+		order.CreatedAt(time.Now().Add(-time.Hour)),
+	)
+	assert.NoError(t, err)
+
+	// an order status can't follow an order and this one has been tampered with
+	os, err := orderstatus.Create(pfiDID, walletDID.URI, "rfq_01hwztehxhe139magy0a18mzms", orderstatus.PAYIN_INITIATED)
+	assert.NoError(t, err)
+	os.Metadata.ExchangeID = exchangeID
+	os.Metadata.CreatedAt = tbdex.Timestamp(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+
+	report := tbdex.VerifyExchange([]tbdex.Message{r, q, o, os})
+	assert.False(t, report.OK())
+	assert.Error(t, report.Err())
+
+	type failure struct {
+		name  tbdex.ExchangeCheckName
+		index int
+	}
+
+	var failures []failure
+	for _, c := range report.Failures() {
+		failures = append(failures, failure{c.Name, c.Index})
+	}
+
+	assert.Equal(t, []failure{
+		{tbdex.CheckParticipants, 2},
+		{tbdex.CheckUniqueID, 2},
+		{tbdex.CheckCreatedAt, 2},
+		{tbdex.CheckSignature, 3},
+		{tbdex.CheckSequence, 3},
+	}, failures)
+}
+
+func TestVerifyExchange_MustStartWithRFQ(t *testing.T) {
+	pfiDID, _ := didjwk.Create()
+	walletDID, _ := didjwk.Create()
+
+	q := createQuote(t, pfiDID, walletDID.URI, "rfq_01hwztehxhe139magy0a18mzms")
+
+	report := tbdex.VerifyExchange([]tbdex.Message{q})
+	assert.False(t, report.OK())
+	assert.Equal(t, tbdex.CheckStartsWithRFQ, report.Failures()[0].Name)
+
+	report = tbdex.VerifyExchange(nil)
+	assert.False(t, report.OK())
+}